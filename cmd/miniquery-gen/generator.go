@@ -0,0 +1,418 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	NamingField  = "field"  // go field name, resolved by gormq
+	NamingJSON   = "json"   // json tag name
+	NamingColumn = "column" // gorm column name
+)
+
+// Generator generate typed filter handles for structs in a package
+type Generator struct {
+	Types   []string // struct names to generate, default all exported
+	Naming  string
+	Package string
+
+	decls map[string]ast.Expr
+	order []string
+}
+
+type genField struct {
+	GoName   string
+	Name     string
+	Handle   string // miniquery handle type
+	Relation string // target struct for relation
+}
+
+type genStruct struct {
+	Name   string
+	Fields []genField
+}
+
+const generatedMarker = "Code generated by miniquery-gen"
+
+// ParseDir parse all non-test go files of package in dir
+func (g *Generator) ParseDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(src, []byte(generatedMarker)) {
+			continue
+		}
+		if err = g.ParseFile(name, src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseFile collect type declarations of a source file
+func (g *Generator) ParseFile(name string, src []byte) error {
+	f, err := parser.ParseFile(token.NewFileSet(), name, src, parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+	if g.Package == "" {
+		g.Package = f.Name.Name
+	} else if g.Package != f.Name.Name {
+		return fmt.Errorf("multiple packages: %q and %q", g.Package, f.Name.Name)
+	}
+	if g.decls == nil {
+		g.decls = map[string]ast.Expr{}
+	}
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.TypeParams != nil {
+				continue
+			}
+			g.decls[ts.Name.Name] = ts.Type
+			g.order = append(g.order, ts.Name.Name)
+		}
+	}
+	return nil
+}
+
+// Generate return formatted source of filter handles
+func (g *Generator) Generate() ([]byte, error) {
+	roots := g.Types
+	if len(roots) == 0 {
+		for _, name := range g.order {
+			if _, ok := g.decls[name].(*ast.StructType); ok && ast.IsExported(name) {
+				roots = append(roots, name)
+			}
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no struct found")
+	}
+
+	structs := map[string]*genStruct{}
+	var queue []string
+	for _, name := range roots {
+		if _, ok := g.decls[name].(*ast.StructType); !ok {
+			return nil, fmt.Errorf("struct not found: %q", name)
+		}
+		queue = append(queue, name)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if structs[name] != nil {
+			continue
+		}
+		s := &genStruct{Name: name}
+		g.collect(s, g.decls[name].(*ast.StructType), map[string]bool{name: true}, "")
+		structs[name] = s
+		for _, f := range s.Fields {
+			if f.Relation != "" {
+				queue = append(queue, f.Relation)
+			}
+		}
+	}
+	g.dropCycles(structs, roots)
+
+	names := make([]string, 0, len(structs))
+	for name := range structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// %s. DO NOT EDIT.\n\npackage %s\n\n", generatedMarker, g.Package)
+	buf.WriteString("import \"github.com/wenerme/go-miniquery/miniquery\"\n\n")
+	for _, name := range roots {
+		fmt.Fprintf(buf, "// %sFilter typed miniquery filter for %s\nvar %sFilter = %s(nil)\n\n", name, name, name, constructorName(name))
+	}
+	for _, name := range names {
+		writeStruct(buf, structs[name])
+	}
+	return format.Source(buf.Bytes())
+}
+
+func writeStruct(buf *bytes.Buffer, s *genStruct) {
+	typ := filterTypeName(s.Name)
+	fmt.Fprintf(buf, "type %s struct {\n", typ)
+	for _, f := range s.Fields {
+		if f.Relation != "" {
+			fmt.Fprintf(buf, "\t%s %s\n", f.GoName, filterTypeName(f.Relation))
+		} else {
+			fmt.Fprintf(buf, "\t%s miniquery.%s\n", f.GoName, f.Handle)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "func %s(prefix []string) %s {\n\treturn %s{\n", constructorName(s.Name), typ, typ)
+	for _, f := range s.Fields {
+		if f.Relation != "" {
+			fmt.Fprintf(buf, "\t\t%s: %s(miniquery.NewField(prefix, %q).Path()),\n", f.GoName, constructorName(f.Relation), f.Name)
+		} else {
+			fmt.Fprintf(buf, "\t\t%s: miniquery.%s{Field: miniquery.NewField(prefix, %q)},\n", f.GoName, f.Handle, f.Name)
+		}
+	}
+	buf.WriteString("\t}\n}\n\n")
+}
+
+// collect fields of struct, prefix is the embeddedPrefix of enclosing embedded structs
+func (g *Generator) collect(s *genStruct, st *ast.StructType, embedding map[string]bool, prefix string) {
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		}
+		gormTag := parseGormTag(tag.Get("gorm"))
+		if _, ok := gormTag["-"]; ok {
+			continue
+		}
+		if len(field.Names) == 0 || gormTag["embedded"] == "true" {
+			g.collectEmbedded(s, field.Type, embedding, prefix+gormTag["embeddedprefix"])
+			continue
+		}
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			f, ok := g.resolve(field.Type)
+			if !ok {
+				continue
+			}
+			f.GoName = ident.Name
+			f.Name = g.fieldName(ident.Name, tag, gormTag, f.Relation != "", prefix)
+			if f.Name == "" {
+				continue
+			}
+			s.Fields = append(s.Fields, f)
+		}
+	}
+}
+
+func (g *Generator) collectEmbedded(s *genStruct, expr ast.Expr, embedding map[string]bool, prefix string) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		st, ok := g.decls[t.Name].(*ast.StructType)
+		if !ok || embedding[t.Name] {
+			return
+		}
+		embedding[t.Name] = true
+		g.collect(s, st, embedding, prefix)
+		delete(embedding, t.Name)
+	case *ast.SelectorExpr:
+		if qualifiedName(t) == "gorm.Model" {
+			s.Fields = append(s.Fields,
+				genField{GoName: "ID", Name: g.modelName("ID", prefix+"id"), Handle: "IntField"},
+				genField{GoName: "CreatedAt", Name: g.modelName("CreatedAt", prefix+"created_at"), Handle: "TimeField"},
+				genField{GoName: "UpdatedAt", Name: g.modelName("UpdatedAt", prefix+"updated_at"), Handle: "TimeField"},
+				genField{GoName: "DeletedAt", Name: g.modelName("DeletedAt", prefix+"deleted_at"), Handle: "TimeField"},
+			)
+		}
+	}
+}
+
+func (g *Generator) modelName(field, column string) string {
+	if g.Naming == NamingColumn {
+		return column
+	}
+	return field
+}
+
+func (g *Generator) fieldName(name string, tag reflect.StructTag, gormTag map[string]string, relation bool, prefix string) string {
+	switch g.Naming {
+	case NamingJSON:
+		if v, ok := tag.Lookup("json"); ok {
+			v, _, _ = strings.Cut(v, ",")
+			switch v {
+			case "-":
+				return ""
+			case "":
+			default:
+				return v
+			}
+		}
+	case NamingColumn:
+		if relation {
+			return name
+		}
+		// gorm prefix the column of embedded fields, including explicit column
+		if v := gormTag["column"]; v != "" {
+			return prefix + v
+		}
+		return prefix + toSnakeCase(name)
+	}
+	return name
+}
+
+var qualifiedHandles = map[string]string{
+	"time.Time":       "TimeField",
+	"gorm.DeletedAt":  "TimeField",
+	"sql.NullTime":    "TimeField",
+	"sql.NullString":  "StringField",
+	"sql.NullBool":    "BoolField",
+	"sql.NullByte":    "IntField",
+	"sql.NullInt16":   "IntField",
+	"sql.NullInt32":   "IntField",
+	"sql.NullInt64":   "IntField",
+	"sql.NullFloat64": "FloatField",
+	"uuid.UUID":       "StringField",
+}
+
+func (g *Generator) resolve(expr ast.Expr) (genField, bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return g.resolve(t.X)
+	case *ast.ArrayType:
+		// has many relation, filtered by exists of any element, slices of values are not filterable
+		if f, ok := g.resolve(t.Elt); ok && f.Relation != "" && t.Len == nil {
+			return f, true
+		}
+		return genField{}, false
+	case *ast.SelectorExpr:
+		if h, ok := qualifiedHandles[qualifiedName(t)]; ok {
+			return genField{Handle: h}, true
+		}
+	case *ast.Ident:
+		if h := basicHandle(t.Name); h != "" {
+			return genField{Handle: h}, true
+		}
+		switch d := g.decls[t.Name].(type) {
+		case *ast.StructType:
+			return genField{Relation: t.Name}, true
+		case nil:
+		default:
+			return g.resolve(d)
+		}
+	}
+	return genField{}, false
+}
+
+func basicHandle(name string) string {
+	switch name {
+	case "string":
+		return "StringField"
+	case "bool":
+		return "BoolField"
+	case "float32", "float64":
+		return "FloatField"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte", "rune":
+		return "IntField"
+	}
+	return ""
+}
+
+// dropCycles remove relations which lead back to the type being expanded, typed filters are values and can not be recursive
+func (g *Generator) dropCycles(structs map[string]*genStruct, roots []string) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		s := structs[name]
+		s.Fields = slices.DeleteFunc(s.Fields, func(f genField) bool {
+			return f.Relation != "" && state[f.Relation] == visiting
+		})
+		for _, f := range s.Fields {
+			if f.Relation != "" && state[f.Relation] == 0 {
+				visit(f.Relation)
+			}
+		}
+		state[name] = visited
+	}
+	for _, name := range roots {
+		if state[name] == 0 {
+			visit(name)
+		}
+	}
+}
+
+func parseGormTag(s string) map[string]string {
+	out := map[string]string{}
+	for _, v := range strings.Split(s, ";") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		k, val, ok := strings.Cut(v, ":")
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "-" {
+			out["-"] = val
+			continue
+		}
+		if !ok {
+			val = "true"
+		}
+		out[k] = val
+	}
+	return out
+}
+
+func qualifiedName(t *ast.SelectorExpr) string {
+	if x, ok := t.X.(*ast.Ident); ok {
+		return x.Name + "." + t.Sel.Name
+	}
+	return ""
+}
+
+func filterTypeName(name string) string {
+	return lowerFirst(name) + "Filter"
+}
+
+func constructorName(name string) string {
+	return "new" + name + "Filter"
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// toSnakeCase follow gorm NamingStrategy for common initialisms
+func toSnakeCase(s string) string {
+	r := []rune(s)
+	buf := strings.Builder{}
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			if i > 0 && (unicode.IsLower(r[i-1]) || (i+1 < len(r) && unicode.IsLower(r[i+1]) && unicode.IsUpper(r[i-1]))) {
+				buf.WriteRune('_')
+			}
+			buf.WriteRune(unicode.ToLower(c))
+		} else {
+			buf.WriteRune(c)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := os.ReadFile("testdata/models.go")
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		Naming   string
+		Contains []string
+		Excludes []string
+	}{
+		{
+			Naming: NamingField,
+			Contains: []string{
+				"var UserFilter = newUserFilter(nil)",
+				`Username: miniquery.StringField{Field: miniquery.NewField(prefix, "Username")},`,
+				`Status:   miniquery.StringField{Field: miniquery.NewField(prefix, "Status")},`,
+				`Score:    miniquery.FloatField{Field: miniquery.NewField(prefix, "Score")},`,
+				`Nickname: miniquery.StringField{Field: miniquery.NewField(prefix, "Nickname")},`,
+				`Profile:  newUserProfileFilter(miniquery.NewField(prefix, "Profile").Path()),`,
+				`Age miniquery.IntField`,
+				`City:     miniquery.StringField{Field: miniquery.NewField(prefix, "City")},`,
+				`Orders:   newOrderFilter(miniquery.NewField(prefix, "Orders").Path()),`,
+				`Total miniquery.FloatField`,
+			},
+			// profile -> user is a cycle
+			Excludes: []string{"Password", "Tags", "secret", "User userFilter", "var UserProfileFilter"},
+		},
+		{
+			Naming: NamingJSON,
+			Contains: []string{
+				`miniquery.NewField(prefix, "username")`,
+				`miniquery.NewField(prefix, "fullName")`,
+				`miniquery.NewField(prefix, "CreatedAt")`,
+			},
+		},
+		{
+			Naming: NamingColumn,
+			Contains: []string{
+				`miniquery.NewField(prefix, "display_name")`,
+				`miniquery.NewField(prefix, "created_at")`,
+				`miniquery.NewField(prefix, "profile_id")`,
+				`miniquery.NewField(prefix, "Profile")`,
+				`miniquery.NewField(prefix, "address_city")`,
+				`miniquery.NewField(prefix, "address_street_name")`,
+				`miniquery.NewField(prefix, "Orders")`,
+			},
+		},
+	} {
+		g := &Generator{Naming: test.Naming, Types: []string{"User"}}
+		assert.NoError(t, g.ParseFile("models.go", src))
+		out, err := g.Generate()
+		if !assert.NoError(t, err) {
+			continue
+		}
+		// ignore gofmt alignment
+		s := strings.Join(strings.Fields(string(out)), " ")
+		for _, v := range test.Contains {
+			assert.Contains(t, s, strings.Join(strings.Fields(v), " "), test.Naming)
+		}
+		for _, v := range test.Excludes {
+			assert.NotContains(t, s, v, test.Naming)
+		}
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	for k, v := range map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"CreatedAt": "created_at",
+		"HTTPCode":  "http_code",
+	} {
+		assert.Equal(t, v, toSnakeCase(k))
+	}
+}
+
+func TestGenerateCompile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("go build")
+	}
+	// inside the module to resolve miniquery import
+	dir, err := os.MkdirTemp("testdata", "build")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	src, err := os.ReadFile("testdata/models.go")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), src, 0o644))
+	for _, naming := range []string{NamingField, NamingJSON, NamingColumn} {
+		g := &Generator{Naming: naming}
+		assert.NoError(t, g.ParseDir(dir))
+		out, err := g.Generate()
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "models_filter.go"), out, 0o644))
+		b, err := exec.Command("go", "build", "./"+dir).CombinedOutput()
+		assert.NoError(t, err, "%s: %s", naming, b)
+	}
+}
@@ -0,0 +1,55 @@
+// Command miniquery-gen generate type-safe miniquery filter builders from go structs
+//
+//	//go:generate go run github.com/wenerme/go-miniquery/cmd/miniquery-gen -type User,UserProfile
+//
+// For type User will generate
+//
+//	var UserFilter = newUserFilter(nil)
+//
+//	UserFilter.Username.Eq("wener")
+//	UserFilter.Profile.Age.Gt(18)
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("miniquery-gen: ")
+
+	var (
+		types  = flag.String("type", "", "comma separated struct names, default all exported structs")
+		output = flag.String("output", "", "output file, default <package>_filter.go")
+		naming = flag.String("naming", NamingField, "field naming: field, json or column")
+		dir    = flag.String("dir", ".", "package directory")
+		v      = flag.Bool("v", false, "print generated file to stderr")
+	)
+	flag.Parse()
+
+	g := &Generator{Naming: *naming}
+	if *types != "" {
+		g.Types = strings.Split(*types, ",")
+	}
+	if err := g.ParseDir(*dir); err != nil {
+		log.Fatal(err)
+	}
+	src, err := g.Generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := *output
+	if out == "" {
+		out = filepath.Join(*dir, g.Package+"_filter.go")
+	}
+	if err = os.WriteFile(out, src, 0o644); err != nil { //nolint:gosec
+		log.Fatal(err)
+	}
+	if *v {
+		log.Println("generated", out)
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Status string
+
+type User struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	Username  string `json:"username"`
+	FullName  string `json:"fullName" gorm:"column:display_name"`
+	Status    Status
+	Score     *float64
+	Active    bool
+	Password  string `json:"-" gorm:"-"`
+	Nickname  sql.NullString
+	ProfileID uint
+	Profile   *UserProfile
+	Tags      []string
+	secret    string
+	Address   Address `gorm:"embedded;embeddedPrefix:address_"`
+	Orders    []Order
+}
+
+type Address struct {
+	City   string
+	Street string `gorm:"column:street_name"`
+}
+
+type Order struct {
+	ID     uint
+	UserID uint
+	Total  float64
+	User   *User
+}
+
+type UserProfile struct {
+	ID   uint
+	Age  int
+	User *User
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+// nodeJSON compact json form of node, only fields of the node type are kept
+func nodeJSON(n *miniquery.Node) map[string]interface{} {
+	if n == nil {
+		return nil
+	}
+	out := map[string]interface{}{"type": n.Type}
+	switch n.Type {
+	case miniquery.ValueNodeType:
+		out["valueType"] = n.ValueType
+		if n.ValueType == miniquery.ArrayValueType {
+			out["value"] = nodesJSON(n.Array)
+		} else {
+			out["value"] = nodeValue(n)
+		}
+	case miniquery.OperationNodeType:
+		out["operation"] = n.Operation
+	case miniquery.IdentifierNodeType, miniquery.ParameterNodeType:
+		out["name"] = n.Name
+	case miniquery.ReferenceNodeType:
+		out["names"] = n.Names
+	case miniquery.FunctionExpressionType:
+		out["name"] = n.Name
+		out["params"] = nodesJSON(n.Params)
+	case miniquery.ParenthesesExpressionType, miniquery.NotExpressionType:
+		out["expression"] = nodeJSON(n.Expression)
+	case miniquery.BetweenExpressionType:
+		out["left"] = nodeJSON(n.Left)
+		out["op"] = n.Op.Operation
+		out["params"] = nodesJSON(n.Params)
+	default:
+		out["left"] = nodeJSON(n.Left)
+		if n.Op != nil {
+			out["op"] = n.Op.Operation
+		}
+		if n.Right != nil {
+			out["right"] = nodeJSON(n.Right)
+		}
+	}
+	return out
+}
+
+func nodesJSON(nodes []*miniquery.Node) []interface{} {
+	out := make([]interface{}, len(nodes))
+	for i, v := range nodes {
+		out[i] = nodeJSON(v)
+	}
+	return out
+}
+
+func nodeValue(n *miniquery.Node) interface{} {
+	switch n.ValueType {
+	case miniquery.TimeValueType:
+		return n.Time.Format(time.RFC3339Nano)
+	case miniquery.UUIDValueType:
+		return fmt.Sprint(n.Value())
+	}
+	return n.Value()
+}
+
+// printTree print indented tree like
+//
+//	logic and
+//	  compare eq
+//	    identifier a
+//	    value int 1
+func printTree(w io.Writer, n *miniquery.Node, indent string) {
+	if n == nil {
+		return
+	}
+	line := string(n.Type)
+	var children []*miniquery.Node
+	switch n.Type {
+	case miniquery.ValueNodeType:
+		line += " " + string(n.ValueType)
+		if n.ValueType == miniquery.ArrayValueType {
+			children = n.Array
+		} else {
+			line += " " + miniquery.Build(n)
+		}
+	case miniquery.OperationNodeType:
+		line += " " + n.Operation
+	case miniquery.IdentifierNodeType:
+		line += " " + n.Name
+	case miniquery.ParameterNodeType:
+		line += " :" + n.Name
+	case miniquery.ReferenceNodeType:
+		line += " " + strings.Join(n.Names, ".")
+	case miniquery.FunctionExpressionType:
+		line += " " + n.Name
+		children = n.Params
+	case miniquery.ParenthesesExpressionType, miniquery.NotExpressionType:
+		children = []*miniquery.Node{n.Expression}
+	case miniquery.BetweenExpressionType:
+		line += " " + n.Op.Operation
+		children = append([]*miniquery.Node{n.Left}, n.Params...)
+	default:
+		if n.Op != nil {
+			line += " " + n.Op.Operation
+		}
+		children = []*miniquery.Node{n.Left}
+		if n.Right != nil {
+			children = append(children, n.Right)
+		}
+	}
+	_, _ = fmt.Fprintln(w, indent+line)
+	for _, v := range children {
+		printTree(w, v, indent+"  ")
+	}
+}
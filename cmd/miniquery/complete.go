@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+// complete the word at pos by miniquery.Complete, return the new line, position and candidates
+func complete(line string, pos int, schema *miniquery.Schema) (string, int, []string) {
+	suggestions := miniquery.Complete(line, pos, schema, nil)
+	if len(suggestions) == 0 {
+		return line, pos, nil
+	}
+	start, end := suggestions[0].Start, suggestions[0].End
+	var candidates []string
+	for _, v := range suggestions {
+		candidates = append(candidates, v.Text)
+	}
+	fill := candidates[0]
+	for _, v := range candidates[1:] {
+		fill = commonPrefix(fill, v)
+	}
+	if len(fill) < pos-start {
+		return line, pos, candidates
+	}
+	if len(candidates) == 1 && end == len(line) && !strings.HasSuffix(fill, "(") {
+		fill += " "
+	}
+	return line[:start] + fill + line[end:], start + len(fill), candidates
+}
+
+func commonPrefix(a, b string) string {
+	n := 0
+	for n < len(a) && n < len(b) && strings.EqualFold(a[n:n+1], b[n:n+1]) {
+		n++
+	}
+	return a[:n]
+}
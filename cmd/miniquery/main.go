@@ -0,0 +1,298 @@
+// Command miniquery parse, format, check and translate miniquery filters
+//
+//	miniquery fmt "a=1 and b in [1,2]"
+//	miniquery ast -format tree "a=1"
+//	miniquery check -schema schema.json "age > 18"
+//	miniquery sql -dialect postgres "createdAt > '2024-01-01'"
+//	miniquery highlight "a = 1 -- one"
+//	miniquery repl -db app.sqlite -table users
+//
+// The query is read from stdin when absent or "-".
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"github.com/wenerme/go-miniquery/entmq"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+const usage = `usage: miniquery <command> [flags] [query]
+
+commands:
+  fmt        print the query in canonical format
+  ast        print the syntax tree as json or tree
+  check      check the query against a json schema
+  sql        print sql and args built by the entsql builder
+  highlight  print the query colored by tokens, or tokens as json
+  repl       interactive query against sqlite table or json file
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type command struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	q      string // last query read
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return 2
+	}
+	c := &command{stdin: stdin, stdout: stdout, stderr: stderr}
+	var err error
+	switch args[0] {
+	case "fmt":
+		err = c.fmt(args[1:])
+	case "ast":
+		err = c.ast(args[1:])
+	case "check":
+		err = c.check(args[1:])
+	case "sql":
+		err = c.sql(args[1:])
+	case "highlight":
+		err = c.highlight(args[1:])
+	case "repl":
+		err = c.repl(args[1:])
+	case "help", "-h", "-help", "--help":
+		_, _ = fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		_, _ = fmt.Fprintf(stderr, "miniquery: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	if err == nil {
+		return 0
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return 2
+	}
+	_, _ = fmt.Fprintln(stderr, "miniquery:", err)
+	var e *miniquery.Error
+	if errors.As(err, &e) && e.Code == miniquery.ErrSyntax && e.Offset >= 0 {
+		// point to the error position
+		q := c.q
+		_, _ = fmt.Fprintf(stderr, "  %s\n  %s^\n", q, strings.Repeat(" ", len([]rune(q[:min(e.Offset, len(q))]))))
+	}
+	return 1
+}
+
+func (c *command) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+// query read and parse query from args or stdin
+func (c *command) query(fs *flag.FlagSet) (*miniquery.Node, error) {
+	q, err := c.read(fs)
+	if err != nil {
+		return nil, err
+	}
+	return miniquery.Parse(q)
+}
+
+// read query from args or stdin
+func (c *command) read(fs *flag.FlagSet) (string, error) {
+	q := strings.Join(fs.Args(), " ")
+	if q == "" || q == "-" {
+		b, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return "", err
+		}
+		q = string(b)
+	}
+	q = strings.TrimSpace(q)
+	c.q = q
+	if q == "" {
+		return "", errors.New("empty query")
+	}
+	return q, nil
+}
+
+func (c *command) fmt(args []string) error {
+	fs := c.flags("fmt")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	node, err := c.query(fs)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.stdout, miniquery.Build(node))
+	return err
+}
+
+func (c *command) ast(args []string) error {
+	fs := c.flags("ast")
+	format := fs.String("format", "json", "output format: json or tree")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	node, err := c.query(fs)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(nodeJSON(node))
+	case "tree":
+		printTree(c.stdout, node, "")
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", *format)
+}
+
+func (c *command) check(args []string) error {
+	fs := c.flags("check")
+	schemaFile := fs.String("schema", "", "schema json file")
+	policy := fs.String("policy", "", "policy spec like `username; Profile.*; -password; date()`")
+	lang := fs.String("lang", miniquery.DefaultLanguage, "message language")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *schemaFile == "" {
+		return errors.New("missing -schema")
+	}
+	schema, err := readSchema(*schemaFile)
+	if err != nil {
+		return err
+	}
+	node, err := c.query(fs)
+	if err != nil {
+		return err
+	}
+	if *policy != "" {
+		p, err := miniquery.ParsePolicy(*policy)
+		if err != nil {
+			return err
+		}
+		if err = p.WithSchema(schema).Enforce(node); err != nil {
+			return errors.New(miniquery.Localize(err, *lang))
+		}
+	}
+	if err = miniquery.Check(node, schema); err != nil {
+		return errors.New(miniquery.Localize(err, *lang))
+	}
+	_, err = fmt.Fprintln(c.stdout, miniquery.Build(node))
+	return err
+}
+
+func (c *command) sql(args []string) error {
+	fs := c.flags("sql")
+	d := fs.String("dialect", "postgres", "sql dialect: postgres, mysql or sqlite")
+	schemaFile := fs.String("schema", "", "schema json file, check and coerce values when set")
+	cast := fs.Bool("cast", false, "cast args by type, postgres only")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dialects := map[string]string{
+		"postgres": dialect.Postgres,
+		"pg":       dialect.Postgres,
+		"mysql":    dialect.MySQL,
+		"sqlite":   dialect.SQLite,
+		"sqlite3":  dialect.SQLite,
+	}
+	name, ok := dialects[*d]
+	if !ok {
+		return fmt.Errorf("unknown dialect %q", *d)
+	}
+	node, err := c.query(fs)
+	if err != nil {
+		return err
+	}
+	if *schemaFile != "" {
+		schema, err := readSchema(*schemaFile)
+		if err != nil {
+			return err
+		}
+		if err = miniquery.Check(node, schema); err != nil {
+			return err
+		}
+	}
+	b := &entmq.MiniQLToEntSQLBuilder{QueryNode: node, DisableTypeCasting: !*cast}
+	b.SetDialect(name)
+	q, qargs := b.Query()
+	if err = b.Err(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(c.stdout, q)
+	for i, v := range qargs {
+		_, _ = fmt.Fprintf(c.stdout, "$%d = %#v\n", i+1, v)
+	}
+	return nil
+}
+
+// tokenColors ansi colors of token kinds
+var tokenColors = map[miniquery.TokenKind]string{
+	miniquery.KeywordToken:   "\x1b[35m",
+	miniquery.OperatorToken:  "\x1b[33m",
+	miniquery.ReferenceToken: "\x1b[36m",
+	miniquery.StringToken:    "\x1b[32m",
+	miniquery.NumberToken:    "\x1b[34m",
+	miniquery.ParameterToken: "\x1b[34m",
+	miniquery.FunctionToken:  "\x1b[1m",
+	miniquery.CommentToken:   "\x1b[90m",
+	miniquery.ErrorToken:     "\x1b[31;4m",
+}
+
+func (c *command) highlight(args []string) error {
+	fs := c.flags("highlight")
+	format := fs.String("format", "ansi", "output format: ansi or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	q, err := c.read(fs)
+	if err != nil {
+		return err
+	}
+	tokens := miniquery.Tokenize(q)
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tokens)
+	case "ansi":
+		var sb strings.Builder
+		last := 0
+		for _, v := range tokens {
+			sb.WriteString(q[last:v.Start])
+			if color := tokenColors[v.Kind]; color != "" {
+				sb.WriteString(color + v.Text + "\x1b[0m")
+			} else {
+				sb.WriteString(v.Text)
+			}
+			last = v.End
+		}
+		sb.WriteString(q[last:])
+		_, err = fmt.Fprintln(c.stdout, sb.String())
+		return err
+	}
+	return fmt.Errorf("unknown format %q", *format)
+}
+
+func readSchema(file string) (*miniquery.Schema, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	schema := &miniquery.Schema{}
+	if err = json.Unmarshal(b, schema); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", file, err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+func TestRun(t *testing.T) {
+	for _, test := range []struct {
+		Args   []string
+		Stdin  string
+		Code   int
+		Out    string
+		ErrOut string
+	}{
+		{Args: []string{"fmt", "a=1 and b in [1,2]"}, Out: "a == 1 && b in [1,2]\n"},
+		{Args: []string{"fmt"}, Stdin: "a = 'x'\n", Out: "a == \"x\"\n"},
+		{Args: []string{"fmt", `path = 'C:\dir' or title = 'say "hi"'`}, Out: "path == \"C:\\dir\" || title == 'say \"hi\"'\n"},
+		{Args: []string{"fmt", "a = 1 and"}, Code: 1, ErrOut: "miniquery: invalid query syntax near \"\"\n  a = 1 and\n           ^\n"},
+		{Args: []string{"highlight", "a = 'x' and b"}, Out: "a \x1b[33m=\x1b[0m \x1b[32m'x'\x1b[0m \x1b[35mand\x1b[0m b\n"},
+		{Args: []string{"highlight", "-format", "json", "a >"}, Out: `[
+  {
+    "kind": "identifier",
+    "text": "a",
+    "start": 0,
+    "end": 1
+  },
+  {
+    "kind": "operator",
+    "text": "\u003e",
+    "start": 2,
+    "end": 3
+  }
+]
+`},
+		{Args: []string{"ast", "-format", "tree", "not a is null"}, Out: "not\n  predicates is null\n    identifier a\n"},
+		{Args: []string{"ast", "Profile.age > 1"}, Out: `{
+  "left": {
+    "names": [
+      "Profile",
+      "age"
+    ],
+    "type": "reference"
+  },
+  "op": "gt",
+  "right": {
+    "type": "value",
+    "value": 1,
+    "valueType": "int"
+  },
+  "type": "compare"
+}
+`},
+		{Args: []string{"check", "-schema", "testdata/schema.json", "age > '18' and Profile.bio like 'go%'"}, Out: "age > 18 && Profile.bio like \"go%\"\n"},
+		{Args: []string{"check", "-schema", "testdata/schema.json", "usrname = 'a'"}, Code: 1, ErrOut: "miniquery: field not found: \"usrname\", did you mean \"username\"?\n"},
+		{Args: []string{"check", "-schema", "testdata/schema.json", "-lang", "zh", "age > 'x'"}, Code: 1},
+		{Args: []string{"check", "-schema", "testdata/schema.json", "-policy", "-age", "age > 1"}, Code: 1, ErrOut: "miniquery: field not found: \"age\"\n"},
+		{Args: []string{"check", "-schema", "testdata/schema.json", "-policy", "age", "age > :min"}, Code: 1, ErrOut: "miniquery: invalid node type \"parameter\"\n"},
+		{Args: []string{"check", "a = 1"}, Code: 1, ErrOut: "miniquery: missing -schema\n"},
+		{Args: []string{"sql", "-dialect", "mysql", "createdAt > 1 and name in ['a','b']"}, Out: "`created_at` > ? AND `name` IN (?, ?)\n$1 = 1\n$2 = \"a\"\n$3 = \"b\"\n"},
+		{Args: []string{"sql", "-schema", "testdata/schema.json", "age = '1'"}, Out: "\"age\" = $1\n$1 = 1\n"},
+		{Args: []string{"sql", "-dialect", "oracle", "a = 1"}, Code: 1},
+		{Args: []string{"nope"}, Code: 2},
+		{Args: nil, Code: 2},
+	} {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := run(test.Args, strings.NewReader(test.Stdin), stdout, stderr)
+		assert.Equal(t, test.Code, code, test.Args)
+		if test.Out != "" {
+			assert.Equal(t, test.Out, stdout.String(), test.Args)
+		}
+		if test.ErrOut != "" {
+			assert.Equal(t, test.ErrOut, stderr.String(), test.Args)
+		}
+	}
+}
+
+func TestFmtStable(t *testing.T) {
+	for _, q := range []string{
+		`path = 'C:\dir'`,
+		`a = "tab\there" and b like '50\%%'`,
+		`title = 'say "hi"' or title = "it's"`,
+		`note = 'it''s "quoted"' and c in ['\', '"', "'"]`,
+	} {
+		in, err := miniquery.Parse(q)
+		if !assert.NoError(t, err, q) {
+			continue
+		}
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		assert.Equal(t, 0, run([]string{"fmt", q}, strings.NewReader(""), stdout, stderr), stderr.String())
+		formatted := strings.TrimSuffix(stdout.String(), "\n")
+		out, err := miniquery.Parse(formatted)
+		if assert.NoError(t, err, formatted) {
+			assert.Equal(t, in, out, formatted)
+		}
+		stdout.Reset()
+		run([]string{"fmt", formatted}, strings.NewReader(""), stdout, stderr)
+		assert.Equal(t, formatted+"\n", stdout.String())
+	}
+}
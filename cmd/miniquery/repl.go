@@ -0,0 +1,425 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/glebarez/sqlite"
+	"github.com/wenerme/go-miniquery/entmq"
+	"github.com/wenerme/go-miniquery/miniquery"
+	"golang.org/x/term"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const replHelp = `enter a query to list matching rows, empty query list all, tab complete fields and operators
+
+  :fields         list fields
+  :order [order]  show or set order like "createdAt desc"
+  :limit [n]      show or set limit
+  :help           show help
+  :quit           exit
+`
+
+func (c *command) repl(args []string) error {
+	fs := c.flags("repl")
+	dbFile := fs.String("db", "", "sqlite database file")
+	table := fs.String("table", "", "table of sqlite database")
+	jsonFile := fs.String("json", "", "json file of array of objects, used instead of -db")
+	limit := fs.Int("limit", 20, "max rows to print")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var src source
+	var err error
+	switch {
+	case *jsonFile != "":
+		src, err = openJSON(*jsonFile)
+	case *dbFile != "" && *table != "":
+		src, err = openSQLite(*dbFile, *table)
+	default:
+		return errors.New("missing -db and -table or -json")
+	}
+	if err != nil {
+		return err
+	}
+	r := &repl{src: src, schema: src.schema(), limit: *limit}
+
+	if f, ok := c.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		return r.runTerminal(f)
+	}
+	sc := bufio.NewScanner(c.stdin)
+	for sc.Scan() {
+		if !r.exec(c.stdout, sc.Text()) {
+			break
+		}
+	}
+	return sc.Err()
+}
+
+// source rows of the repl
+type source interface {
+	schema() *miniquery.Schema
+	// query return sql and args if any, columns and rows
+	query(node *miniquery.Node, order string, limit int) (*result, error)
+}
+
+type result struct {
+	SQL     string
+	Args    []interface{}
+	Columns []string
+	Rows    [][]interface{}
+}
+
+type repl struct {
+	src    source
+	schema *miniquery.Schema
+	order  string
+	limit  int
+}
+
+func (r *repl) runTerminal(f *os.File) error {
+	state, err := term.MakeRaw(int(f.Fd()))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = term.Restore(int(f.Fd()), state)
+	}()
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{f, os.Stdout}, "miniquery> ")
+	t.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		out, n, candidates := complete(line, pos, r.schema)
+		if len(candidates) > 1 {
+			_, _ = fmt.Fprintln(t, strings.Join(candidates, "  "))
+		}
+		return out, n, out != line
+	}
+	_, _ = fmt.Fprint(t, replHelp)
+	for {
+		line, err := t.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !r.exec(t, line) {
+			return nil
+		}
+	}
+}
+
+// exec execute line, return false to exit
+func (r *repl) exec(w io.Writer, line string) bool {
+	line = strings.TrimSpace(line)
+	if cmd, arg, ok := strings.Cut(line+" ", " "); strings.HasPrefix(line, ":") && ok {
+		arg = strings.TrimSpace(arg)
+		switch cmd {
+		case ":q", ":quit", ":exit":
+			return false
+		case ":help":
+			_, _ = fmt.Fprint(w, replHelp)
+		case ":fields":
+			for _, f := range r.schema.Fields {
+				_, _ = fmt.Fprintf(w, "%s\t%s\n", f.Name, f.Type)
+			}
+		case ":order":
+			if arg != "" {
+				if err := r.checkOrder(arg); err != nil {
+					_, _ = fmt.Fprintln(w, "error:", err)
+					break
+				}
+				r.order = arg
+			}
+			_, _ = fmt.Fprintln(w, "order:", r.order)
+		case ":limit":
+			if arg != "" {
+				n, err := strconv.Atoi(arg)
+				if err != nil || n <= 0 {
+					_, _ = fmt.Fprintln(w, "error: invalid limit", arg)
+					break
+				}
+				r.limit = n
+			}
+			_, _ = fmt.Fprintln(w, "limit:", r.limit)
+		default:
+			_, _ = fmt.Fprintf(w, "error: unknown command %s\n", cmd)
+		}
+		return true
+	}
+
+	res, err := r.query(line)
+	if err != nil {
+		_, _ = fmt.Fprintln(w, "error:", err)
+		return true
+	}
+	if res.SQL != "" {
+		_, _ = fmt.Fprintln(w, "sql:", res.SQL)
+		_, _ = fmt.Fprintf(w, "args: %v\n", res.Args)
+	}
+	printRows(w, res)
+	return true
+}
+
+func (r *repl) query(q string) (*result, error) {
+	var node *miniquery.Node
+	if q != "" {
+		var err error
+		if node, err = miniquery.Parse(q); err != nil {
+			return nil, err
+		}
+		if err = miniquery.Check(node, r.schema); err != nil {
+			return nil, err
+		}
+	}
+	return r.src.query(node, r.order, r.limit)
+}
+
+func (r *repl) checkOrder(order string) error {
+	orders, err := miniquery.ParseOrder(order)
+	if err != nil {
+		return err
+	}
+	return miniquery.CheckOrder(orders, r.schema)
+}
+
+func printRows(w io.Writer, res *result) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join(res.Columns, "\t"))
+	for _, row := range res.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = formatCell(v)
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "(%d rows)\n", len(res.Rows))
+}
+
+func formatCell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+type sqliteSource struct {
+	db    *gorm.DB
+	table string
+	s     *miniquery.Schema
+}
+
+func openSQLite(file string, table string) (*sqliteSource, error) {
+	if _, err := os.Stat(file); err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(sqlite.Open(file), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, err
+	}
+	types, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("table %q not found", table)
+	}
+	s := &miniquery.Schema{Name: table}
+	for _, v := range types {
+		nullable, _ := v.Nullable()
+		s.Fields = append(s.Fields, &miniquery.SchemaField{Name: v.Name(), Type: sqliteFieldType(v.DatabaseTypeName()), Nullable: nullable})
+	}
+	return &sqliteSource{db: db, table: table, s: s}, nil
+}
+
+// sqliteFieldType field type by sqlite type affinity
+func sqliteFieldType(t string) miniquery.FieldType {
+	t = strings.ToLower(t)
+	switch {
+	case strings.Contains(t, "bool"):
+		return miniquery.BoolFieldType
+	case strings.Contains(t, "int"):
+		return miniquery.IntFieldType
+	case strings.Contains(t, "date"), strings.Contains(t, "time"):
+		return miniquery.TimeFieldType
+	case strings.Contains(t, "real"), strings.Contains(t, "floa"), strings.Contains(t, "doub"), strings.Contains(t, "numeric"), strings.Contains(t, "decimal"):
+		return miniquery.FloatFieldType
+	case strings.Contains(t, "json"):
+		return miniquery.JSONFieldType
+	}
+	return miniquery.StringFieldType
+}
+
+func (s *sqliteSource) schema() *miniquery.Schema {
+	return s.s
+}
+
+func (s *sqliteSource) query(node *miniquery.Node, order string, limit int) (*result, error) {
+	sel := sql.Dialect(dialect.SQLite).Select("*").From(sql.Table(s.table))
+	if node != nil {
+		sel.Where(sql.P(func(b *sql.Builder) {
+			// values are checked and coerced by schema
+			mb := &entmq.MiniQLToEntSQLBuilder{QueryNode: node, DisableTypeCasting: true, Selector: sel}
+			mb.SetDialect(dialect.SQLite)
+			b.Join(mb)
+			if err := mb.Err(); err != nil {
+				sel.AddError(err)
+			}
+		}))
+	}
+	if order != "" {
+		fn, err := entmq.BuildEntSQLOrder(order, nil)
+		if err != nil {
+			return nil, err
+		}
+		fn(sel)
+	}
+	sel.Limit(limit)
+	q, args := sel.Query()
+	if err := sel.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Raw(q, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := &result{SQL: q, Args: args}
+	if res.Columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		row := make([]interface{}, len(res.Columns))
+		ptrs := make([]interface{}, len(row))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err = rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	return res, rows.Err()
+}
+
+type jsonSource struct {
+	rows    []map[string]interface{}
+	columns []string
+	s       *miniquery.Schema
+}
+
+func openJSON(file string) (*jsonSource, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	src := &jsonSource{s: &miniquery.Schema{}}
+	if err = json.Unmarshal(b, &src.rows); err != nil {
+		return nil, fmt.Errorf("invalid json %s: %w", file, err)
+	}
+	types := map[string]miniquery.FieldType{}
+	for _, row := range src.rows {
+		for k, v := range row {
+			if _, ok := types[k]; !ok || v != nil && types[k] == "" {
+				types[k] = jsonFieldType(v)
+			}
+		}
+	}
+	for k := range types {
+		src.columns = append(src.columns, k)
+	}
+	sort.Strings(src.columns)
+	for _, k := range src.columns {
+		t := types[k]
+		if t == "" {
+			t = miniquery.StringFieldType
+		}
+		src.s.Fields = append(src.s.Fields, &miniquery.SchemaField{Name: k, Type: t, Nullable: true})
+	}
+	return src, nil
+}
+
+func jsonFieldType(v interface{}) miniquery.FieldType {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return miniquery.BoolFieldType
+	case float64:
+		if v == float64(int64(v)) {
+			return miniquery.IntFieldType
+		}
+		return miniquery.FloatFieldType
+	case string:
+		if _, err := time.Parse(time.RFC3339, v); err == nil {
+			return miniquery.TimeFieldType
+		}
+		return miniquery.StringFieldType
+	}
+	return miniquery.JSONFieldType
+}
+
+func (s *jsonSource) schema() *miniquery.Schema {
+	return s.s
+}
+
+func (s *jsonSource) query(node *miniquery.Node, order string, limit int) (*result, error) {
+	rows := s.rows
+	if node != nil {
+		match, err := miniquery.Compile[map[string]interface{}](node)
+		if err != nil {
+			return nil, err
+		}
+		rows = nil
+		for _, row := range s.rows {
+			if match(row) {
+				rows = append(rows, row)
+			}
+		}
+	} else {
+		rows = append([]map[string]interface{}(nil), rows...)
+	}
+	if err := miniquery.SortSlice(rows, order); err != nil {
+		return nil, err
+	}
+	res := &result{Columns: s.columns}
+	for _, row := range rows[:min(limit, len(rows))] {
+		cells := make([]interface{}, len(s.columns))
+		for i, k := range s.columns {
+			cells[i] = row[k]
+		}
+		res.Rows = append(res.Rows, cells)
+	}
+	return res, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wenerme/go-miniquery/miniquery"
+	"gorm.io/gorm"
+)
+
+func TestReplJSON(t *testing.T) {
+	input := strings.Join([]string{
+		`:fields`,
+		`age > 18`,
+		`:order age desc`,
+		`age > 18`,
+		`:order nope`,
+		`usrname = 'a'`,
+		`:limit 1`,
+		``,
+		`:quit`,
+		`id = 1`,
+	}, "\n")
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run([]string{"repl", "-json", "testdata/users.json"}, strings.NewReader(input), stdout, stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, `age	int
+createdAt	time
+id	int
+score	float
+username	string
+age  createdAt             id  score  username
+30   2024-02-01T00:00:00Z  2   NULL   alice
+25   2023-12-01T00:00:00Z  3   3.5    bob
+(2 rows)
+order: age desc
+age  createdAt             id  score  username
+30   2024-02-01T00:00:00Z  2   NULL   alice
+25   2023-12-01T00:00:00Z  3   3.5    bob
+(2 rows)
+error: field not found: "nope"
+error: field not found: "usrname", did you mean "username"?
+limit: 1
+age  createdAt             id  score  username
+30   2024-02-01T00:00:00Z  2   NULL   alice
+(1 rows)
+`, stdout.String())
+}
+
+func TestReplSQLite(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "test.sqlite")
+	type User struct {
+		ID        int
+		Username  string
+		Age       *int
+		CreatedAt time.Time
+	}
+	db, err := gorm.Open(sqlite.Open(file), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(User{}))
+	age := 20
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.Create([]User{{ID: 1, Username: "wener", Age: &age, CreatedAt: ts}, {ID: 2, Username: "bob", CreatedAt: ts.AddDate(0, 1, 0)}}).Error)
+
+	input := "createdAt > '2024-01-15' and username like 'b%'\n:order age desc nulls last, id\nid > 0\nage >\n"
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run([]string{"repl", "-db", file, "-table", "users"}, strings.NewReader(input), stdout, stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	out := stdout.String()
+	assert.Contains(t, out, "sql: SELECT * FROM `users` WHERE `users`.`created_at` > ? AND `users`.`username` LIKE ? LIMIT 20\nargs: [2024-01-15 00:00:00 +0000 UTC b%]\n")
+	assert.Contains(t, out, "2   bob       NULL  2024-02-01T00:00:00Z\n(1 rows)\n")
+	assert.Contains(t, out, "ORDER BY CASE WHEN `users`.`age` IS NULL THEN 1 ELSE 0 END, `users`.`age` DESC, `users`.`id` ASC LIMIT 20\n")
+	assert.Contains(t, out, "1   wener     20    2024-01-01T00:00:00Z\n2   bob       NULL  2024-02-01T00:00:00Z\n(2 rows)\n")
+	assert.Contains(t, out, "error: invalid query syntax near \"\"\n")
+
+	assert.Equal(t, 1, run([]string{"repl", "-db", file, "-table", "missing"}, strings.NewReader(""), stdout, stderr))
+	assert.Equal(t, 1, run([]string{"repl"}, strings.NewReader(""), stdout, stderr))
+}
+
+func TestComplete(t *testing.T) {
+	schema := &miniquery.Schema{
+		Fields: []*miniquery.SchemaField{{Name: "username"}, {Name: "userId"}, {Name: "age"}},
+		Relations: map[string]*miniquery.Schema{
+			"Profile": {Fields: []*miniquery.SchemaField{{Name: "bio"}}},
+		},
+	}
+	for _, test := range []struct {
+		Line       string
+		Pos        int
+		E          string
+		EPos       int
+		Candidates []string
+	}{
+		{Line: "ag", Pos: 2, E: "age ", EPos: 4, Candidates: []string{"age"}},
+		{Line: "us = 1", Pos: 2, E: "user = 1", EPos: 4, Candidates: []string{"username", "userId"}},
+		{Line: "age > 1 an", Pos: 10, E: "age > 1 and ", EPos: 12, Candidates: []string{"and"}},
+		{Line: "prof", Pos: 4, E: "Profile.bio ", EPos: 12, Candidates: []string{"Profile.bio"}},
+		{Line: "age ", Pos: 4, E: "age ", EPos: 4, Candidates: []string{"is null", "is not null", "and", "or"}},
+		{Line: "age is", Pos: 6, E: "age is n", EPos: 8, Candidates: []string{"is null", "is not null"}},
+		{Line: "d", Pos: 1, E: "date(", EPos: 5, Candidates: []string{"date("}},
+		{Line: "xyz", Pos: 3, E: "xyz", EPos: 3},
+	} {
+		out, pos, candidates := complete(test.Line, test.Pos, schema)
+		assert.Equal(t, test.E, out, test.Line)
+		assert.Equal(t, test.EPos, pos, test.Line)
+		assert.Equal(t, test.Candidates, candidates, test.Line)
+	}
+}
@@ -1,10 +1,11 @@
 package entmq
 
 import (
+	"fmt"
 	"log/slog"
+	"time"
 
 	"entgo.io/ent/entql"
-	"github.com/pkg/errors"
 	"github.com/wenerme/go-miniquery/miniquery"
 )
 
@@ -13,7 +14,7 @@ func ApplyEntQL(q interface{ Where(p entql.P) }, queries []string) error {
 		mb := MiniQLToEntQLBuilder{Query: v}
 		p, err := mb.Build()
 		if err != nil {
-			return errors.Wrapf(err, "failed to apply query: %q", v)
+			return fmt.Errorf("failed to apply query %q: %w", v, err)
 		}
 		q.Where(p)
 	}
@@ -24,14 +25,22 @@ func BuildEntQL(v string) (entql.P, error) {
 	mb := MiniQLToEntQLBuilder{Query: v}
 	p, err := mb.Build()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to build query: %q", v)
+		return nil, fmt.Errorf("failed to build query %q: %w", v, err)
 	}
 	return p, nil
 }
 
 type MiniQLToEntQLBuilder struct {
 	Query string
-	stack []entql.Expr
+	// Policy restrict filterable fields and operators, applied to public field names
+	Policy *miniquery.Policy
+	// Resolver map public field names to ent field names
+	Resolver miniquery.FieldResolver
+	// Virtuals virtual fields expanded before building
+	Virtuals *miniquery.Virtuals
+	// Params bind :name parameters of query and virtual fields
+	Params map[string]interface{}
+	stack  []entql.Expr
 }
 
 func (mb *MiniQLToEntQLBuilder) pop() entql.Expr {
@@ -52,6 +61,16 @@ func (mb *MiniQLToEntQLBuilder) Build() (p entql.P, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = mb.Policy.Enforce(node); err != nil {
+		return nil, err
+	}
+	// resolve public names before the definitions of virtual fields are expanded
+	if err = miniquery.Resolve(node, mb.Virtuals.Resolver(mb.Resolver)); err != nil {
+		return nil, err
+	}
+	if node, err = miniquery.Expand(node, mb.Virtuals, mb.Params); err != nil {
+		return nil, err
+	}
 	err = mb.visit(node)
 	if err == nil && len(mb.stack) > 0 {
 		p = mb.pop().(entql.P)
@@ -67,31 +86,30 @@ func (mb *MiniQLToEntQLBuilder) visit(node *miniquery.Node) (err error) {
 
 	switch node.Type {
 	case miniquery.OperationNodeType:
-		return errors.Errorf("unexpected op node: %q", node)
+		return miniquery.NewError(miniquery.ErrInvalidNode).WithValue(string(node.Type))
 	case miniquery.ValueNodeType:
 		mb.push(&entql.Value{V: node.Value()})
 	case miniquery.IdentifierNodeType:
+		// virtual column is expanded by miniquery.Expand
 		mb.push(entql.F(node.Name))
-		/*
-			switch name {
-			case "owned":
-				buf.WriteString("(owner_id is not null)")
-				return
-			case "unowned":
-				buf.WriteString("(owner_id is null)")
-				return
-			}
-		*/
 	case miniquery.ParenthesesExpressionType:
 		err = visit(node.Expression)
 	case miniquery.NotExpressionType:
 		err = visit(node.Expression)
-		if err != nil {
+		if err == nil {
 			mb.push(entql.Not(mb.pop().(entql.P)))
 		}
 	case miniquery.FunctionExpressionType:
-		// err = qb.visitFunction(node)
-
+		switch node.Name {
+		case "now":
+			// entql has no sql function, current time is bound when building
+			if len(node.Params) != 0 {
+				return miniquery.NewError(miniquery.ErrInvalidArguments).WithFunction(node.Name).WithExpected("no arguments")
+			}
+			mb.push(&entql.Value{V: time.Now()})
+		default:
+			return miniquery.NewError(miniquery.ErrUnsupportedFunction).WithFunction(node.Name).WithSuggestions(node.Name, []string{"now"})
+		}
 	case miniquery.BetweenExpressionType:
 		err = visit(node.Left)
 		if err == nil {
@@ -125,7 +143,28 @@ func (mb *MiniQLToEntQLBuilder) visit(node *miniquery.Node) (err error) {
 
 		}
 	case miniquery.PredicatesExpressionType:
-		panic("TODO")
+		// null is nil value of entql
+		var y *entql.Value
+		op, not := entql.OpEQ, false
+		switch node.Op.Operation {
+		case miniquery.OpIsNull:
+		case miniquery.OpIsNotNull:
+			op = entql.OpNEQ
+		case miniquery.OpIsTrue, miniquery.OpIsNotTrue:
+			y, not = &entql.Value{V: true}, node.Op.Operation == miniquery.OpIsNotTrue
+		case miniquery.OpIsFalse, miniquery.OpIsNotFalse:
+			y, not = &entql.Value{V: false}, node.Op.Operation == miniquery.OpIsNotFalse
+		default:
+			return miniquery.NewError(miniquery.ErrUnsupportedOperator).WithOperator(node.Op.Operation)
+		}
+		err = visit(node.Left)
+		if err == nil {
+			var p entql.P = &entql.BinaryExpr{Op: op, X: mb.pop(), Y: y}
+			if not {
+				p = entql.Not(p)
+			}
+			mb.push(p)
+		}
 	case miniquery.LogicExpressionType:
 		fallthrough
 	case miniquery.CompareExpressionType:
@@ -138,7 +177,7 @@ func (mb *MiniQLToEntQLBuilder) visit(node *miniquery.Node) (err error) {
 			left := mb.pop()
 			op, found := entqlOpMap[node.Op.Operation]
 			if !found {
-				return errors.Errorf("unexpected op %q", node.Op.Operation)
+				return miniquery.NewError(miniquery.ErrUnsupportedOperator).WithOperator(node.Op.Operation)
 			}
 			mb.push(&entql.BinaryExpr{
 				Op: op,
@@ -147,7 +186,7 @@ func (mb *MiniQLToEntQLBuilder) visit(node *miniquery.Node) (err error) {
 			})
 		}
 	default:
-		return errors.Errorf("invalid type %q", node.Type)
+		return miniquery.NewError(miniquery.ErrInvalidNode).WithValue(string(node.Type))
 	}
 	return err
 }
@@ -2,8 +2,12 @@ package entmq_test
 
 import (
 	"testing"
+	"time"
+
+	"entgo.io/ent/entql"
 
 	"github.com/wenerme/go-miniquery/entmq"
+	"github.com/wenerme/go-miniquery/miniquery"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -58,6 +62,10 @@ func TestQLBuilder(t *testing.T) {
 		{Q: "a not between 1 and 3", E: "a < 1 && a > 3"},
 		{Q: "true and false", E: "true && false"},
 		{Q: "a in [1 , 2 , 3]", E: "a in [1,2,3]"},
+		{Q: "a is null", E: "a == nil"},
+		{Q: "a is not null", E: "a != nil"},
+		{Q: "a is true", E: "a == true"},
+		{Q: "a is not false", E: "!(a == false)"},
 	} {
 		b := &entmq.MiniQLToEntQLBuilder{
 			Query: test.Q,
@@ -67,3 +75,74 @@ func TestQLBuilder(t *testing.T) {
 		assert.Equal(t, test.E, ql.String())
 	}
 }
+
+func TestQLBuilderPolicy(t *testing.T) {
+	policy, err := miniquery.ParsePolicy("a: gt,lt; -secret")
+	assert.NoError(t, err)
+	b := &entmq.MiniQLToEntQLBuilder{Query: "a > 1", Policy: policy}
+	_, err = b.Build()
+	assert.NoError(t, err)
+	b = &entmq.MiniQLToEntQLBuilder{Query: "secret > 1", Policy: policy}
+	_, err = b.Build()
+	assert.EqualError(t, err, `field not found: "secret"`)
+	b = &entmq.MiniQLToEntQLBuilder{Query: "a > :min", Policy: policy, Params: map[string]interface{}{"min": 1}}
+	p, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "a > 1", p.String())
+}
+
+func TestQLBuilderResolver(t *testing.T) {
+	resolver := &miniquery.FieldMapping{Aliases: map[string]string{"createdAt": "created_at"}}
+	b := &entmq.MiniQLToEntQLBuilder{Query: "createdAt > 1", Resolver: resolver}
+	p, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at > 1", p.String())
+}
+
+func TestQLBuilderVirtuals(t *testing.T) {
+	virtuals := (&miniquery.Virtuals{}).MustDefine("owned", `owner_id > 0`)
+	b := &entmq.MiniQLToEntQLBuilder{Query: "owned is false", Virtuals: virtuals}
+	p, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "!(owner_id > 0)", p.String())
+
+	b = &entmq.MiniQLToEntQLBuilder{Query: "owned and age > 1", Virtuals: virtuals, Resolver: &miniquery.FieldMapping{Aliases: map[string]string{"age": "age"}, Strict: true}}
+	p, err = b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "owner_id > 0 && age > 1", p.String())
+}
+
+func TestQLBuilderVirtualPredicates(t *testing.T) {
+	virtuals := (&miniquery.Virtuals{}).
+		MustDefine("owned", `owner_id is not null`).
+		MustDefine("overdue", `due_at < now()`)
+
+	b := &entmq.MiniQLToEntQLBuilder{Query: "owned", Virtuals: virtuals}
+	p, err := b.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "owner_id != nil", p.String())
+
+	b = &entmq.MiniQLToEntQLBuilder{Query: "overdue and owned = false", Virtuals: virtuals}
+	p, err = b.Build()
+	assert.NoError(t, err)
+	if assert.IsType(t, &entql.BinaryExpr{}, p) {
+		overdue := p.(*entql.BinaryExpr).X.(*entql.BinaryExpr)
+		assert.Equal(t, entql.OpLT, overdue.Op)
+		assert.Equal(t, "due_at", overdue.X.(*entql.Field).Name)
+		assert.IsType(t, time.Time{}, overdue.Y.(*entql.Value).V)
+		assert.Equal(t, "!(owner_id != nil)", p.(*entql.BinaryExpr).Y.String())
+	}
+
+	b = &entmq.MiniQLToEntQLBuilder{Query: "date(due_at) = '2021-05-12'"}
+	_, err = b.Build()
+	assert.ErrorIs(t, err, miniquery.ErrUnsupportedFunction)
+}
+
+func TestBuildEntQLError(t *testing.T) {
+	_, err := entmq.BuildEntQL(`a = `)
+	assert.ErrorIs(t, err, miniquery.ErrSyntax)
+	assert.ErrorContains(t, err, `failed to build query "a = "`)
+
+	_, err = entmq.BuildEntQL(`unknown(a)`)
+	assert.ErrorIs(t, err, miniquery.ErrUnsupportedFunction)
+}
@@ -1,46 +1,183 @@
 package entmq
 
 import (
+	"errors"
 	"reflect"
+	"sort"
 	"strings"
 	"unsafe"
 
 	"github.com/wenerme/go-miniquery/miniquery"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/huandu/xstrings"
-	"github.com/pkg/errors"
 )
 
 type MiniQLToEntSQLBuilder struct {
 	Node        *sqlgraph.Node
 	QueryString string
-	ast         *miniquery.Node
-	SQLBuilder  *sql.Builder
-	Graph       *sqlgraph.Schema
+	// QueryNode parsed query used instead of QueryString, e.g. checked by miniquery.Check
+	QueryNode  *miniquery.Node
+	ast        *miniquery.Node
+	SQLBuilder *sql.Builder
+	Graph      *sqlgraph.Schema
 	sql.Builder
 	DisableTypeCasting bool
+	// Check type check the query and coerce values by schema derived from Node
+	Check bool
+	// Policy restrict filterable fields and operators, applied to public field names,
+	// names are canonicalized by schema of Node unless mapped by Resolver
+	Policy *miniquery.Policy
+	// Resolver map public field names before resolving columns
+	Resolver miniquery.FieldResolver
+	// Virtuals virtual fields expanded before building
+	Virtuals *miniquery.Virtuals
+	// Params bind :name parameters of query and virtual fields
+	Params map[string]interface{}
+	// Order order of Cursor, ordering is applied by EntSQLOrderBuilder
+	Order string
+	// Cursor select rows after the cursor of Order, see miniquery.NewCursor
+	Cursor string
+	// Selector qualify columns by the selector table, required when the selector joins other tables
+	Selector *sql.Selector
+	// joined aliases of edges joined by order of Cursor, references of keyset are qualified by edge alias
+	joined map[string]bool
+	errs   []error
+}
+
+// AddError keep the typed error, sql.Builder flatten errors to string
+func (mb *MiniQLToEntSQLBuilder) AddError(err error) *sql.Builder {
+	if err != nil {
+		mb.errs = append(mb.errs, err)
+	}
+	return mb.Builder.AddError(err)
+}
+
+// Err return the errors of query, errors.Is and errors.As work with miniquery.Error
+func (mb *MiniQLToEntSQLBuilder) Err() error {
+	if len(mb.errs) != 0 {
+		return errors.Join(mb.errs...)
+	}
+	return mb.Builder.Err()
+}
+
+// Functions supported by MiniQLToEntSQLBuilder
+var Functions = []string{"date", "now", "has_edge", "has"}
+
+func edgeNames(n *sqlgraph.Node) []string {
+	out := make([]string, 0, len(n.Edges))
+	for k := range n.Edges {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
 }
 
 // Query impl sql.Querier
 func (mb *MiniQLToEntSQLBuilder) Query() (string, []interface{}) {
+	policy := policyOf(mb.Policy, mb.Node, mb.Resolver)
 	if mb.ast == nil {
-		ast, err := miniquery.Parse(mb.QueryString)
+		ast, err := mb.parse()
 		if err != nil {
 			mb.AddError(err)
 			return "", nil
 		}
+		if err = policy.Enforce(ast); err != nil {
+			mb.AddError(err)
+			return "", nil
+		}
+		// resolve public names before the definitions of virtual fields are expanded
+		if err = miniquery.Resolve(ast, mb.Virtuals.Resolver(mb.Resolver)); err != nil {
+			mb.AddError(err)
+			return "", nil
+		}
+		if ast, err = miniquery.Expand(ast, mb.Virtuals, mb.Params); err != nil {
+			mb.AddError(err)
+			return "", nil
+		}
+		if err = mb.join(); err != nil {
+			mb.AddError(policy.Redact(err))
+			return "", nil
+		}
+		if mb.Check && mb.Node != nil {
+			if err = miniquery.Check(ast, SchemaOf(mb.Node)); err != nil {
+				mb.AddError(policy.Redact(err))
+				return "", nil
+			}
+		}
 		mb.ast = ast
 	}
 	err := mb.visit(mb.ast)
 	if err != nil {
-		mb.AddError(err)
+		mb.AddError(policy.Redact(err))
 		return "", nil
 	}
 	return mb.Builder.Query()
 }
 
+// parse query and keyset of Cursor
+func (mb *MiniQLToEntSQLBuilder) parse() (*miniquery.Node, error) {
+	var ast *miniquery.Node
+	switch {
+	case mb.QueryNode != nil:
+		ast = mb.QueryNode.Clone()
+	case mb.QueryString != "" || mb.Cursor == "":
+		var err error
+		if ast, err = miniquery.Parse(mb.QueryString); err != nil {
+			return nil, err
+		}
+	}
+	if mb.Cursor != "" {
+		orders, err := miniquery.ParseOrder(mb.Order)
+		if err != nil {
+			return nil, err
+		}
+		keyset, err := miniquery.KeysetCursor(orders, mb.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		ast = miniquery.And(ast, keyset)
+	}
+	return ast, nil
+}
+
+// join check order of Cursor is unique and record edges joined by EntSQLOrderBuilder
+func (mb *MiniQLToEntSQLBuilder) join() error {
+	if mb.Cursor == "" || mb.Node == nil {
+		return nil
+	}
+	orders, err := miniquery.ParseOrder(mb.Order)
+	if err != nil {
+		return err
+	}
+	ob := &EntSQLOrderBuilder{Node: mb.Node}
+	schema := SchemaOf(mb.Node)
+	for _, o := range orders {
+		if err = miniquery.Resolve(o.Field, mb.Resolver); err != nil {
+			return err
+		}
+	}
+	if err = miniquery.CheckKeyset(orders, schema); err != nil {
+		return err
+	}
+	for _, o := range orders {
+		if o.Field.Type != miniquery.ReferenceNodeType {
+			continue
+		}
+		edges, _, err := ob.reference(o.Field, schema)
+		if err != nil {
+			return err
+		}
+		if mb.joined == nil {
+			mb.joined = map[string]bool{}
+		}
+		mb.joined[alias(edges)] = true
+	}
+	return nil
+}
+
 //nolint:golint,gocyclo
 func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 	visit := mb.visit
@@ -50,7 +187,7 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 	}
 	switch node.Type {
 	case miniquery.OperationNodeType:
-		return errors.Errorf("unexpected op node: %q", node)
+		return miniquery.NewError(miniquery.ErrInvalidNode).WithValue(string(node.Type))
 	case miniquery.ValueNodeType:
 		if node.ValueType == miniquery.ArrayValueType {
 			s.WriteString("(")
@@ -66,17 +203,26 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 		}
 	case miniquery.IdentifierNodeType:
 		// fixme 检测字段是否存在
-		s.Ident(xstrings.ToSnakeCase(node.Name))
-		/*
-			switch name {
-			case "owned":
-				buf.WriteString("(owner_id is not null)")
-				return
-			case "unowned":
-				buf.WriteString("(owner_id is null)")
-				return
-			}
-		*/
+		// virtual column is expanded by miniquery.Expand
+		col := xstrings.ToSnakeCase(node.Name)
+		if mb.Selector != nil {
+			col = mb.Selector.C(col)
+		}
+		s.Ident(col)
+	case miniquery.ReferenceNodeType:
+		// only edges joined by the order of Cursor, the selector must be ordered by EntSQLOrderBuilder
+		if len(mb.joined) == 0 {
+			return miniquery.NewError(miniquery.ErrUnsupportedReference).WithField(node.Names...)
+		}
+		ob := &EntSQLOrderBuilder{Node: mb.Node}
+		edges, col, rerr := ob.reference(node, SchemaOf(mb.Node))
+		if rerr != nil {
+			return rerr
+		}
+		if !mb.joined[alias(edges)] {
+			return miniquery.NewError(miniquery.ErrUnsupportedReference).WithField(node.Names...)
+		}
+		s.Ident(alias(edges)).WriteByte('.').Ident(col)
 	case miniquery.ParenthesesExpressionType:
 		s.WriteString("(")
 		err = visit(node.Expression)
@@ -93,19 +239,26 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 			s.WriteString("DATE(")
 			err = mb.visit(node.Params[0])
 			s.WriteString(")")
-		case "has_edge":
+		case "now":
+			s.WriteString("CURRENT_TIMESTAMP")
+		case "has_edge", "has":
 
 			params := node.Params
 			if len(params) == 0 || params[0].Name == "" {
-				err = errors.New("关联参数错误")
+				err = miniquery.NewError(miniquery.ErrInvalidEdgeArgs).WithFunction(node.Name).WithValue(miniquery.Build(node))
 				break
 			}
 
 			tab := mb.Node
+			if tab == nil {
+				// edges are resolved by Node
+				err = miniquery.NewError(miniquery.ErrUnknownRelation).WithField(params[0].Name)
+				break
+			}
 			builder := sql.Dialect(mb.Dialect()).Select().From(sql.Table(tab.Table).Schema(tab.Schema))
 			edge, ok := tab.Edges[params[0].Name]
 			if !ok {
-				err = errors.Errorf("不存在关联: %q", params[0].Name)
+				err = miniquery.NewError(miniquery.ErrUnknownRelation).WithField(params[0].Name).WithSuggestions(params[0].Name, edgeNames(tab))
 				break
 			}
 			switch len(params) {
@@ -117,7 +270,7 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 				))
 			case 2:
 				if !params[1].IsExpression() {
-					err = errors.Errorf("关联条件错误: %q", params[1].String())
+					err = miniquery.NewError(miniquery.ErrInvalidEdgeArgs).WithFunction(node.Name).WithValue(miniquery.Build(node))
 					break
 				}
 				sqlgraph.HasNeighborsWith(builder, sqlgraph.NewStep(
@@ -126,20 +279,20 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 					sqlgraph.Edge(edge.Spec.Rel, edge.Spec.Inverse, edge.Spec.Table, edge.Spec.Columns...),
 				), func(selector *sql.Selector) {
 					selector.Where(sql.P(func(builder *sql.Builder) {
-						mb := &MiniQLToEntSQLBuilder{
+						sub := &MiniQLToEntSQLBuilder{
 							ast:                params[1],
 							Node:               edge.To,
 							DisableTypeCasting: mb.DisableTypeCasting,
 						}
-						builder.Join(mb)
-						err := mb.Err()
-						if err != nil {
+						builder.Join(sub)
+						if err := sub.Err(); err != nil {
+							mb.errs = append(mb.errs, err)
 							builder.AddError(err)
 						}
 					}))
 				})
 			default:
-				err = errors.New("关联参数错误")
+				err = miniquery.NewError(miniquery.ErrInvalidEdgeArgs).WithFunction(node.Name).WithValue(miniquery.Build(node))
 			}
 			if err == nil {
 				// hack reflect access
@@ -151,7 +304,7 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 				mb.Join(p)
 			}
 		default:
-			err = errors.Errorf("不支持的函数: %q", node.Name)
+			err = miniquery.NewError(miniquery.ErrUnsupportedFunction).WithFunction(node.Name).WithSuggestions(node.Name, Functions)
 		}
 	case miniquery.BetweenExpressionType:
 		lo := sql.OpGTE
@@ -179,7 +332,7 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 	case miniquery.PredicatesExpressionType:
 		op, found := entsqlOpMap[node.Op.Operation]
 		if !found {
-			return errors.Errorf("unexpected predicate op %q", node.Op.Operation)
+			return miniquery.NewError(miniquery.ErrUnsupportedOperator).WithOperator(node.Op.Operation)
 		}
 		err = visit(node.Left)
 		s.WriteOp(op)
@@ -202,13 +355,18 @@ func (mb *MiniQLToEntSQLBuilder) visit(node *miniquery.Node) (err error) {
 			s.WriteOp(op)
 		}
 		if !found {
-			return errors.Errorf("unexpected op %q", node.Op.Operation)
+			return miniquery.NewError(miniquery.ErrUnsupportedOperator).WithOperator(node.Op.Operation)
 		}
 		if err == nil {
 			err = visit(node.Right)
 		}
+		// \ is the default escape character of like except sqlite
+		if op := node.Op.Operation; err == nil && (op == miniquery.OpLike || op == miniquery.OpNotLike) && mb.Dialect() == dialect.SQLite &&
+			node.Right.Type == miniquery.ValueNodeType && strings.Contains(node.Right.Str, `\`) {
+			s.WriteString(" ESCAPE ").Arg(`\`)
+		}
 	default:
-		return errors.Errorf("invalid type %q", node.Type)
+		return miniquery.NewError(miniquery.ErrInvalidNode).WithValue(string(node.Type))
 	}
 	return err
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/wenerme/go-miniquery/entmq"
+	"github.com/wenerme/go-miniquery/miniquery"
 
 	"entgo.io/ent/dialect"
 	"github.com/stretchr/testify/assert"
@@ -39,3 +40,96 @@ func TestEntSQL(t *testing.T) {
 		assert.EqualValues(t, test.Args, args)
 	}
 }
+
+func TestEntSQLLikeEscape(t *testing.T) {
+	q := miniquery.Build(miniquery.StringField{Field: miniquery.NewField(nil, "name")}.HasPrefix("50%"))
+	for _, test := range []struct {
+		Dialect string
+		E       string
+	}{
+		{Dialect: dialect.SQLite, E: "`name` LIKE ? ESCAPE ?"},
+		{Dialect: dialect.Postgres, E: `"name" LIKE $1`},
+	} {
+		b := &entmq.MiniQLToEntSQLBuilder{QueryString: q, DisableTypeCasting: true}
+		b.SetDialect(test.Dialect)
+		s, args := b.Query()
+		assert.NoError(t, b.Err())
+		assert.Equal(t, test.E, s)
+		assert.Equal(t, `50\%%`, args[0])
+	}
+}
+
+func TestEntSQLResolver(t *testing.T) {
+	resolver := &miniquery.FieldMapping{Aliases: map[string]string{"status": "state_code"}}
+	b := &entmq.MiniQLToEntSQLBuilder{QueryString: "status = 'a'", Resolver: resolver, DisableTypeCasting: true}
+	b.SetDialect(dialect.Postgres)
+	s, _ := b.Query()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, `"state_code" = $1`, s)
+}
+
+func TestEntSQLVirtuals(t *testing.T) {
+	virtuals := (&miniquery.Virtuals{}).MustDefine("overdue", `due_at < now() and status != 'done'`)
+	b := &entmq.MiniQLToEntSQLBuilder{QueryString: "overdue = false and owner_id = :me", Virtuals: virtuals, Params: map[string]interface{}{"me": 1}, DisableTypeCasting: true}
+	b.SetDialect(dialect.Postgres)
+	s, args := b.Query()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, `NOT ("due_at" < CURRENT_TIMESTAMP AND "status" <> $1) AND "owner_id" = $2`, s)
+	assert.EqualValues(t, []interface{}{"done", 1}, args)
+
+	// definitions use backend names, only the query is resolved
+	resolver := &miniquery.FieldMapping{Aliases: map[string]string{"owner": "owner_id"}, Strict: true}
+	b = &entmq.MiniQLToEntSQLBuilder{QueryString: "overdue and owner = 1", Virtuals: virtuals, Resolver: resolver, DisableTypeCasting: true}
+	b.SetDialect(dialect.Postgres)
+	s, _ = b.Query()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, `("due_at" < CURRENT_TIMESTAMP AND "status" <> $1) AND "owner_id" = $2`, s)
+	b = &entmq.MiniQLToEntSQLBuilder{QueryString: "overdue and due_at > 1", Virtuals: virtuals, Resolver: resolver}
+	b.Query()
+	assert.ErrorIs(t, b.Err(), miniquery.ErrUnknownField)
+}
+
+func TestEntSQLErrors(t *testing.T) {
+	user := newTestGraph().Nodes[0]
+	for _, test := range []struct {
+		Q    string
+		Code miniquery.ErrorCode
+		ZH   string
+	}{
+		{Q: `has_edge(missing)`, Code: miniquery.ErrUnknownRelation, ZH: `不存在关联: "missing"`},
+		{Q: `has_edge(order)`, Code: miniquery.ErrUnknownRelation, ZH: `不存在关联: "order"，是否要使用 "orders"？`},
+		{Q: `now_() = 1`, Code: miniquery.ErrUnsupportedFunction, ZH: `不支持的函数: "now_"，是否要使用 "now"？`},
+		{Q: `has_edge()`, Code: miniquery.ErrInvalidEdgeArgs, ZH: `关联参数错误: has_edge()`},
+		{Q: `has_edge(orders, unknown(a))`, Code: miniquery.ErrUnsupportedFunction},
+		{Q: `unknown(a)`, Code: miniquery.ErrUnsupportedFunction, ZH: `不支持的函数: "unknown"`},
+		{Q: `a = `, Code: miniquery.ErrSyntax},
+	} {
+		b := &entmq.MiniQLToEntSQLBuilder{QueryString: test.Q, Node: user}
+		b.SetDialect(dialect.Postgres)
+		b.Query()
+		assert.ErrorIs(t, b.Err(), test.Code, test.Q)
+		if test.ZH != "" {
+			assert.Equal(t, test.ZH, miniquery.Localize(b.Err(), "zh"), test.Q)
+		}
+	}
+}
+
+func TestEntSQLEdgeWithoutNode(t *testing.T) {
+	b := &entmq.MiniQLToEntSQLBuilder{QueryString: `has_edge(orders)`}
+	b.SetDialect(dialect.Postgres)
+	b.Query()
+	assert.ErrorIs(t, b.Err(), miniquery.ErrUnknownRelation)
+}
+
+func TestEntSQLHas(t *testing.T) {
+	user := newTestGraph().Nodes[0]
+	query := func(q string) string {
+		b := &entmq.MiniQLToEntSQLBuilder{QueryString: q, Node: user}
+		b.SetDialect(dialect.Postgres)
+		s, _ := b.Query()
+		assert.NoError(t, b.Err(), q)
+		return s
+	}
+	assert.Equal(t, query(`has_edge(orders)`), query(`has(orders)`))
+	assert.Equal(t, query(`has_edge(orders, total > 1)`), query(`has(orders, total > 1)`))
+}
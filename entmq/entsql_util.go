@@ -5,6 +5,7 @@ import (
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
 )
 
 func argWithCast(in interface{}) interface{} {
@@ -29,6 +30,8 @@ func (f typeCastParamFormatter) FormatParam(placeholder string, info *sql.StmtIn
 			typ = "bool"
 		case float64:
 			typ = "double precision"
+		case uuid.UUID:
+			typ = "uuid"
 		default:
 			return placeholder
 		}
@@ -0,0 +1,145 @@
+package entmq
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+// EntSQLListBuilder build selector functions of list request
+type EntSQLListBuilder struct {
+	Request *miniquery.ListRequest
+	Node    *sqlgraph.Node
+	Graph   *sqlgraph.Schema
+	// DisableTypeCasting see MiniQLToEntSQLBuilder
+	DisableTypeCasting bool
+	// Check type check the query and coerce values by schema derived from Node
+	Check bool
+	// Policy restrict filterable fields and operators, applied to public field names,
+	// names are canonicalized by schema of Node unless mapped by Resolver
+	Policy *miniquery.Policy
+	// Resolver map public field names before resolving columns
+	Resolver miniquery.FieldResolver
+	// Virtuals virtual fields expanded before building
+	Virtuals *miniquery.Virtuals
+	// Params bind :name parameters of query and virtual fields
+	Params map[string]interface{}
+}
+
+// BuildEntSQLList build list and total count selector functions of list request
+//
+//	list, count, err := entmq.BuildEntSQLList(req, graph.Nodes[0])
+//	total, err := client.User.Query().Where(count).Count(ctx)
+//	users, err := client.User.Query().Modify(list).All(ctx)
+func BuildEntSQLList(req *miniquery.ListRequest, node *sqlgraph.Node) (list func(s *sql.Selector), count func(s *sql.Selector), err error) {
+	b := EntSQLListBuilder{Request: req, Node: node}
+	return b.Build()
+}
+
+// Build validate the request, list apply filter, order, projection and pagination,
+// count apply only the filter
+func (b *EntSQLListBuilder) Build() (list func(s *sql.Selector), count func(s *sql.Selector), err error) {
+	req := b.Request
+	if req.Query != "" || req.Cursor != "" {
+		mb := b.filter(nil, req.Cursor)
+		mb.Query()
+		if err = mb.Err(); err != nil {
+			return nil, nil, err
+		}
+	}
+	ob := &EntSQLOrderBuilder{Order: req.Order, Node: b.Node, Policy: b.Policy, Resolver: b.Resolver}
+	order, err := ob.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	columns, err := b.columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	where := func(s *sql.Selector, cursor string) {
+		s.Where(sql.P(func(builder *sql.Builder) {
+			mb := b.filter(s, cursor)
+			builder.Join(mb)
+			if err := mb.Err(); err != nil {
+				s.AddError(err)
+			}
+		}))
+	}
+	list = func(s *sql.Selector) {
+		if req.Query != "" || req.Cursor != "" {
+			where(s, req.Cursor)
+		}
+		order(s)
+		if len(columns) != 0 {
+			selected := make([]string, len(columns))
+			for i, v := range columns {
+				selected[i] = s.C(v)
+			}
+			s.Select(selected...)
+		}
+		if req.Limit > 0 {
+			s.Limit(req.Limit)
+		}
+		if req.Offset > 0 {
+			s.Offset(req.Offset)
+		}
+	}
+	count = func(s *sql.Selector) {
+		if req.Query != "" {
+			where(s, "")
+		}
+	}
+	return list, count, nil
+}
+
+func (b *EntSQLListBuilder) filter(s *sql.Selector, cursor string) *MiniQLToEntSQLBuilder {
+	mb := &MiniQLToEntSQLBuilder{
+		Node:               b.Node,
+		Graph:              b.Graph,
+		QueryString:        b.Request.Query,
+		Check:              b.Check,
+		DisableTypeCasting: b.DisableTypeCasting,
+		Policy:             b.Policy,
+		Resolver:           b.Resolver,
+		Virtuals:           b.Virtuals,
+		Params:             b.Params,
+		Order:              b.Request.Order,
+		Cursor:             cursor,
+		Selector:           s,
+	}
+	if s != nil {
+		mb.SetDialect(s.Dialect())
+	}
+	return mb
+}
+
+// columns resolve projection fields to columns of Node
+func (b *EntSQLListBuilder) columns() ([]string, error) {
+	var schema *miniquery.Schema
+	if b.Node != nil {
+		schema = SchemaOf(b.Node)
+	}
+	policy := policyOf(b.Policy, b.Node, b.Resolver)
+	var out []string
+	for _, f := range b.Request.Fields {
+		names := []string{f}
+		if policy != nil && !policy.AllowField(names, "") {
+			return nil, miniquery.NewError(miniquery.ErrUnknownField).WithField(f)
+		}
+		if b.Resolver != nil {
+			var err error
+			if names, err = b.Resolver.ResolveField(names); err != nil {
+				return nil, err
+			}
+		}
+		if len(names) != 1 {
+			return nil, miniquery.NewError(miniquery.ErrUnsupportedReference).WithField(names...)
+		}
+		if schema != nil && schema.Field(names[0]) == nil {
+			return nil, policy.Redact(miniquery.NewError(miniquery.ErrUnknownField).WithField(f).WithSuggestions(f, schema.FieldNames()))
+		}
+		out = append(out, column(schema, names[0]))
+	}
+	return out, nil
+}
@@ -0,0 +1,77 @@
+package entmq_test
+
+import (
+	"net/url"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wenerme/go-miniquery/entmq"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+func TestEntSQLList(t *testing.T) {
+	graph := newTestGraph()
+	users, orders := graph.Nodes[0], graph.Nodes[1]
+	values, _ := url.ParseQuery(`q=age+>+18&order=createdAt+desc&limit=10&offset=20&fields=id,username`)
+	req, err := miniquery.ParseListRequest(values, &miniquery.ListOptions{Schema: entmq.SchemaOf(users)})
+	require.NoError(t, err)
+
+	b := &entmq.EntSQLListBuilder{Request: req, Node: users, Graph: graph, Check: true, DisableTypeCasting: true}
+	list, count, err := b.Build()
+	require.NoError(t, err)
+	s := sql.Dialect(dialect.Postgres).Select().From(sql.Table(users.Table))
+	list(s)
+	q, args := s.Query()
+	assert.NoError(t, s.Err())
+	assert.Equal(t, `SELECT "users"."id", "users"."username" FROM "users" WHERE "users"."age" > $1 ORDER BY "users"."created_at" DESC LIMIT 10 OFFSET 20`, q)
+	assert.Equal(t, []interface{}{18}, args)
+
+	s = sql.Dialect(dialect.Postgres).Select(sql.Count("*")).From(sql.Table(users.Table))
+	count(s)
+	q, _ = s.Query()
+	assert.Equal(t, `SELECT COUNT(*) FROM "users" WHERE "users"."age" > $1`, q)
+
+	// filter columns are qualified when order joins
+	list, _, err = entmq.BuildEntSQLList(&miniquery.ListRequest{Query: `id is not null`, Order: `owner.age`}, orders)
+	require.NoError(t, err)
+	s = sql.Dialect(dialect.Postgres).Select().From(sql.Table(orders.Table))
+	list(s)
+	q, _ = s.Query()
+	assert.Equal(t, `SELECT * FROM "orders" LEFT JOIN "users" AS "owner" ON "orders"."user_id" = "owner"."id" WHERE "orders"."id" IS NOT NULL ORDER BY "owner"."age" ASC`, q)
+
+	// next page of relation order, keyset is qualified by the joined edge alias
+	orderBy, err := miniquery.ParseOrder(`owner.age desc, id`)
+	require.NoError(t, err)
+	cursor, err := miniquery.EncodeCursor(orderBy, []interface{}{18, "a"})
+	require.NoError(t, err)
+	b = &entmq.EntSQLListBuilder{Request: &miniquery.ListRequest{Query: `total > 1`, Order: `owner.age desc, id`, Cursor: cursor, Limit: 10}, Node: orders, DisableTypeCasting: true}
+	list, _, err = b.Build()
+	require.NoError(t, err)
+	s = sql.Dialect(dialect.Postgres).Select().From(sql.Table(orders.Table))
+	list(s)
+	q, _ = s.Query()
+	assert.NoError(t, s.Err())
+	assert.Equal(t, `SELECT * FROM "orders" LEFT JOIN "users" AS "owner" ON "orders"."user_id" = "owner"."id" WHERE "orders"."total" > $1 AND ("owner"."age" < $2 OR ("owner"."age" = $3 AND "orders"."id" > $4)) ORDER BY "owner"."age" DESC, "orders"."id" ASC LIMIT 10`, q)
+
+	for _, req := range []*miniquery.ListRequest{
+		{Query: `age >`},
+		{Order: `orders.total`},
+		{Fields: []string{"passwd"}},
+		{Fields: []string{"owner.age"}},
+		{Order: `id`, Cursor: `abc`},
+	} {
+		_, _, err = entmq.BuildEntSQLList(req, users)
+		assert.Error(t, err, req)
+	}
+
+	// order without unique field skip or repeat rows of tied values
+	orderBy, err = miniquery.ParseOrder(`owner.age desc`)
+	require.NoError(t, err)
+	cursor, err = miniquery.EncodeCursor(orderBy, []interface{}{18})
+	require.NoError(t, err)
+	_, _, err = entmq.BuildEntSQLList(&miniquery.ListRequest{Order: `owner.age desc`, Cursor: cursor}, orders)
+	assert.ErrorIs(t, err, miniquery.ErrNonUniqueOrder)
+}
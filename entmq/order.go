@@ -0,0 +1,203 @@
+package entmq
+
+import (
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/huandu/xstrings"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+// EntSQLOrderBuilder build selector order from order string like `createdAt desc, owner.name asc nulls last`
+type EntSQLOrderBuilder struct {
+	Order string
+	// Node resolve columns and to-one edges, edges are left joined and nested edges like owner.company.name
+	// are aliased as owner__company, without Node identifiers are snake cased
+	Node *sqlgraph.Node
+	// Policy restrict sortable fields, applied to public field names, canonicalized by schema of Node unless mapped by Resolver
+	Policy *miniquery.Policy
+	// Resolver map public field names before resolving columns
+	Resolver miniquery.FieldResolver
+}
+
+// BuildEntSQLOrder build order function for ent query Order or Modify
+func BuildEntSQLOrder(order string, node *sqlgraph.Node) (func(s *sql.Selector), error) {
+	b := EntSQLOrderBuilder{Order: order, Node: node}
+	return b.Build()
+}
+
+// Build validate the order and return the selector function
+func (b *EntSQLOrderBuilder) Build() (func(s *sql.Selector), error) {
+	orders, err := miniquery.ParseOrder(b.Order)
+	if err != nil {
+		return nil, err
+	}
+	policy := policyOf(b.Policy, b.Node, b.Resolver)
+	for _, o := range orders {
+		if err = policy.Enforce(o.Field); err != nil {
+			return nil, err
+		}
+		if err = miniquery.Resolve(o.Field, b.Resolver); err != nil {
+			return nil, err
+		}
+	}
+	var schema *miniquery.Schema
+	if b.Node != nil {
+		schema = SchemaOf(b.Node)
+		if err = miniquery.CheckOrder(orders, schema); err != nil {
+			return nil, policy.Redact(err)
+		}
+	}
+	// validate expressions before returning the selector function
+	for _, o := range orders {
+		if err = b.visit(o.Field, schema, nil, nil, &sql.Builder{}); err != nil {
+			return nil, policy.Redact(err)
+		}
+	}
+	return func(s *sql.Selector) {
+		joined := map[string]*sql.SelectTable{}
+		for _, o := range orders {
+			expr := &sql.Builder{}
+			expr.SetDialect(s.Dialect())
+			if err := b.visit(o.Field, schema, s, joined, expr); err != nil {
+				s.AddError(err)
+				return
+			}
+			s.OrderExpr(orderExpr(expr, o))
+		}
+	}, nil
+}
+
+// visit write order expression, selector is nil when validating
+func (b *EntSQLOrderBuilder) visit(node *miniquery.Node, schema *miniquery.Schema, s *sql.Selector, joined map[string]*sql.SelectTable, w *sql.Builder) error {
+	switch node.Type {
+	case miniquery.IdentifierNodeType:
+		if s == nil {
+			return nil
+		}
+		// qualified as joined tables may have the same column
+		w.WriteString(s.C(column(schema, node.Name)))
+	case miniquery.ReferenceNodeType:
+		edges, col, err := b.reference(node, schema)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return nil
+		}
+		w.WriteString(b.join(s, joined, edges).C(col))
+	case miniquery.FunctionExpressionType:
+		switch node.Name {
+		case "date":
+			if len(node.Params) != 1 {
+				return miniquery.NewError(miniquery.ErrInvalidArguments).WithFunction(node.Name).WithExpected("1 argument")
+			}
+			w.WriteString("DATE(")
+			if err := b.visit(node.Params[0], schema, s, joined, w); err != nil {
+				return err
+			}
+			w.WriteString(")")
+		default:
+			return miniquery.NewError(miniquery.ErrUnsupportedFunction).WithFunction(node.Name).WithSuggestions(node.Name, []string{"date"})
+		}
+	default:
+		return miniquery.NewError(miniquery.ErrInvalidOrder).WithValue(miniquery.Build(node))
+	}
+	return nil
+}
+
+// reference resolve to-one edges and column of reference like owner.company.name,
+// edges are joined with alias of the edge path, see alias
+func (b *EntSQLOrderBuilder) reference(node *miniquery.Node, schema *miniquery.Schema) (edges []string, col string, err error) {
+	if len(node.Names) < 2 || b.Node == nil {
+		return nil, "", miniquery.NewError(miniquery.ErrUnsupportedReference).WithField(node.Names...)
+	}
+	n := b.Node
+	for i, name := range node.Names[:len(node.Names)-1] {
+		edge, ok := edgeOf(n, name)
+		if !ok {
+			return nil, "", miniquery.NewError(miniquery.ErrUnknownRelation).WithField(node.Names[:i+1]...).WithSuggestions(name, edgeNames(n))
+		}
+		e := n.Edges[edge]
+		if rel := e.Spec.Rel; rel == sqlgraph.O2M || rel == sqlgraph.M2M {
+			return nil, "", miniquery.NewError(miniquery.ErrUnsortableField).WithField(node.Names[:i+1]...)
+		}
+		edges = append(edges, edge)
+		if schema != nil {
+			schema = schema.Relation(edge)
+		}
+		n = e.To
+	}
+	return edges, column(schema, node.Names[len(node.Names)-1]), nil
+}
+
+// edgeOf find edge of node by name case-insensitively
+func edgeOf(n *sqlgraph.Node, name string) (string, bool) {
+	for _, k := range edgeNames(n) {
+		if k == name || xstrings.ToSnakeCase(k) == xstrings.ToSnakeCase(name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// alias table alias of joined edge path, edge name for direct edge and owner__company for nested edges
+func alias(edges []string) string {
+	return strings.Join(edges, "__")
+}
+
+// join left join each to-one edge of path once, aliased by alias of the path prefix
+func (b *EntSQLOrderBuilder) join(s *sql.Selector, joined map[string]*sql.SelectTable, edges []string) *sql.SelectTable {
+	n := b.Node
+	parent := s.C
+	var t *sql.SelectTable
+	for i, name := range edges {
+		e := n.Edges[name]
+		as := alias(edges[:i+1])
+		if t = joined[as]; t == nil {
+			t = sql.Table(e.To.Table).Schema(e.To.Schema).As(as)
+			if e.Spec.Table == n.Table {
+				// foreign key in this table
+				s.LeftJoin(t).On(parent(e.Spec.Columns[0]), t.C(e.To.ID.Column))
+			} else {
+				s.LeftJoin(t).On(parent(n.ID.Column), t.C(e.Spec.Columns[0]))
+			}
+			joined[as] = t
+		}
+		parent = t.C
+		n = e.To
+	}
+	return t
+}
+
+// column resolve column by schema, fallback to snake case like MiniQLToEntSQLBuilder
+func column(schema *miniquery.Schema, name string) string {
+	if schema != nil {
+		if f := schema.Field(name); f != nil && f.Column != "" {
+			return f.Column
+		}
+	}
+	return xstrings.ToSnakeCase(name)
+}
+
+// orderExpr NULLS FIRST/LAST is emulated by case for databases without it
+func orderExpr(expr *sql.Builder, o *miniquery.Order) sql.Querier {
+	e := expr.String()
+	return sql.ExprFunc(func(b *sql.Builder) {
+		if o.Nulls != "" {
+			b.WriteString("CASE WHEN ").WriteString(e)
+			if o.Nulls == miniquery.NullsFirst {
+				b.WriteString(" IS NULL THEN 0 ELSE 1 END, ")
+			} else {
+				b.WriteString(" IS NULL THEN 1 ELSE 0 END, ")
+			}
+		}
+		b.WriteString(e)
+		if o.Desc {
+			b.WriteString(" DESC")
+		} else {
+			b.WriteString(" ASC")
+		}
+	})
+}
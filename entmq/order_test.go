@@ -0,0 +1,112 @@
+package entmq_test
+
+import (
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/go-miniquery/entmq"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+func TestEntSQLOrder(t *testing.T) {
+	graph := newTestGraph()
+	users, orders := graph.Nodes[0], graph.Nodes[1]
+	for _, test := range []struct {
+		Order string
+		Node  int
+		E     string
+		Err   error
+	}{
+		{Order: "username desc, createdAt", E: `SELECT * FROM "users" ORDER BY "users"."username" DESC, "users"."created_at" ASC`},
+		{Order: "age nulls last", E: `SELECT * FROM "users" ORDER BY CASE WHEN "users"."age" IS NULL THEN 1 ELSE 0 END, "users"."age" ASC`},
+		{Order: "date(createdAt) desc", E: `SELECT * FROM "users" ORDER BY DATE("users"."created_at") DESC`},
+		{Order: "owner.age desc, owner.username", Node: 1, E: `SELECT * FROM "orders" LEFT JOIN "users" AS "owner" ON "orders"."user_id" = "owner"."id" ORDER BY "owner"."age" DESC, "owner"."username" ASC`},
+		{Order: "owner.age, id", Node: 1, E: `SELECT * FROM "orders" LEFT JOIN "users" AS "owner" ON "orders"."user_id" = "owner"."id" ORDER BY "owner"."age" ASC, "orders"."id" ASC`},
+		{Order: "owner.company.name desc, owner.age", Node: 1, E: `SELECT * FROM "orders" LEFT JOIN "users" AS "owner" ON "orders"."user_id" = "owner"."id" LEFT JOIN "companies" AS "owner__company" ON "owner"."company_id" = "owner__company"."id" ORDER BY "owner__company"."name" DESC, "owner"."age" ASC`},
+		{Order: "company.name", E: `SELECT * FROM "users" LEFT JOIN "companies" AS "company" ON "users"."company_id" = "company"."id" ORDER BY "company"."name" ASC`},
+		{Order: "orders.total", Err: miniquery.ErrUnsortableField},
+		{Order: "owner.orders.total", Node: 1, Err: miniquery.ErrUnsortableField},
+		{Order: "owner.compny.name", Node: 1, Err: miniquery.ErrUnknownRelation},
+		{Order: "nmae", Err: miniquery.ErrUnknownField},
+		{Order: "age = 1", Err: miniquery.ErrInvalidOrder},
+	} {
+		node := users
+		if test.Node == 1 {
+			node = orders
+		}
+		fn, err := entmq.BuildEntSQLOrder(test.Order, node)
+		if test.Err != nil {
+			assert.ErrorIs(t, err, test.Err, test.Order)
+			continue
+		}
+		if !assert.NoError(t, err, test.Order) {
+			continue
+		}
+		s := sql.Dialect(dialect.Postgres).Select().From(sql.Table(node.Table))
+		fn(s)
+		q, _ := s.Query()
+		assert.NoError(t, s.Err())
+		assert.Equal(t, test.E, q, test.Order)
+	}
+}
+
+func TestEntSQLCursor(t *testing.T) {
+	graph := newTestGraph()
+	orders, err := miniquery.ParseOrder(`age desc, id`)
+	assert.NoError(t, err)
+	cursor, err := miniquery.EncodeCursor(orders, []interface{}{18, 10})
+	assert.NoError(t, err)
+
+	b := &entmq.MiniQLToEntSQLBuilder{QueryString: `username like 'w%'`, Node: graph.Nodes[0], DisableTypeCasting: true, Order: `age desc, id`, Cursor: cursor}
+	b.SetDialect(dialect.Postgres)
+	q, args := b.Query()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, `"username" LIKE $1 AND ("age" < $2 OR ("age" = $3 AND "id" > $4))`, q)
+	assert.Equal(t, []interface{}{"w%", 18, 18, 10}, args)
+
+	// relation order, the edge is joined and aliased by EntSQLOrderBuilder
+	order := `owner.age desc, id`
+	orders, err = miniquery.ParseOrder(order)
+	assert.NoError(t, err)
+	cursor, err = miniquery.EncodeCursor(orders, []interface{}{18, "a"})
+	assert.NoError(t, err)
+	fn, err := entmq.BuildEntSQLOrder(order, graph.Nodes[1])
+	assert.NoError(t, err)
+	s := sql.Dialect(dialect.Postgres).Select().From(sql.Table(graph.Nodes[1].Table))
+	fn(s)
+	s.Where(sql.P(func(builder *sql.Builder) {
+		b := &entmq.MiniQLToEntSQLBuilder{Node: graph.Nodes[1], DisableTypeCasting: true, Order: order, Cursor: cursor, Selector: s}
+		builder.Join(b)
+		assert.NoError(t, b.Err())
+	}))
+	q, args = s.Query()
+	assert.NoError(t, s.Err())
+	assert.Equal(t, `SELECT * FROM "orders" LEFT JOIN "users" AS "owner" ON "orders"."user_id" = "owner"."id" WHERE ("owner"."age" < $1 OR ("owner"."age" = $2 AND "orders"."id" > $3)) ORDER BY "owner"."age" DESC, "orders"."id" ASC`, q)
+	assert.Equal(t, []interface{}{18, 18, "a"}, args)
+
+	// nested relation order
+	order = `owner.company.name, id`
+	orders, err = miniquery.ParseOrder(order)
+	assert.NoError(t, err)
+	cursor, err = miniquery.EncodeCursor(orders, []interface{}{"acme", "a"})
+	assert.NoError(t, err)
+	list, _, err := entmq.BuildEntSQLList(&miniquery.ListRequest{Order: order, Cursor: cursor}, graph.Nodes[1])
+	assert.NoError(t, err)
+	s = sql.Dialect(dialect.Postgres).Select().From(sql.Table(graph.Nodes[1].Table))
+	list(s)
+	q, _ = s.Query()
+	assert.NoError(t, s.Err())
+	assert.Equal(t, `SELECT * FROM "orders" LEFT JOIN "users" AS "owner" ON "orders"."user_id" = "owner"."id" LEFT JOIN "companies" AS "owner__company" ON "owner"."company_id" = "owner__company"."id" `+
+		`WHERE ("owner__company"."name" > $1 OR ("owner__company"."name" = $2 AND "orders"."id" > $3)) ORDER BY "owner__company"."name" ASC, "orders"."id" ASC`, q)
+
+	// references are only supported for edges joined by order
+	b = &entmq.MiniQLToEntSQLBuilder{QueryString: `owner.age > 1`, Node: graph.Nodes[1], Order: `id`}
+	b.Query()
+	assert.ErrorIs(t, b.Err(), miniquery.ErrUnsupportedReference)
+
+	b = &entmq.MiniQLToEntSQLBuilder{Node: graph.Nodes[0], Order: `id`, Cursor: cursor}
+	b.Query()
+	assert.ErrorIs(t, b.Err(), miniquery.ErrInvalidCursor)
+}
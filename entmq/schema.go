@@ -0,0 +1,86 @@
+package entmq
+
+import (
+	"sort"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+// SchemaOf derive miniquery schema from ent graph node, edges are derived recursively
+func SchemaOf(n *sqlgraph.Node) *miniquery.Schema {
+	return schemaOf(n, map[*sqlgraph.Node]*miniquery.Schema{})
+}
+
+// Describe describe filterable fields of ent graph node under policy, see miniquery.Describe
+func Describe(n *sqlgraph.Node, p *miniquery.Policy) *miniquery.Description {
+	return miniquery.Describe(SchemaOf(n), p, Functions)
+}
+
+// policyOf canonicalize field names of policy by schema of node, so a field can not bypass the policy by its column name,
+// public names mapped by resolver are matched as is
+func policyOf(p *miniquery.Policy, n *sqlgraph.Node, r miniquery.FieldResolver) *miniquery.Policy {
+	if p == nil || n == nil || r != nil {
+		return p
+	}
+	return p.WithSchema(SchemaOf(n))
+}
+
+func schemaOf(n *sqlgraph.Node, seen map[*sqlgraph.Node]*miniquery.Schema) *miniquery.Schema {
+	if s, ok := seen[n]; ok {
+		return s
+	}
+	s := &miniquery.Schema{Name: n.Type}
+	seen[n] = s
+	if n.ID != nil {
+		if typ, ok := fieldType(n.ID.Type); ok {
+			s.Fields = append(s.Fields, &miniquery.SchemaField{Name: "id", Column: n.ID.Column, Type: typ, Unique: true})
+		}
+	}
+	names := make([]string, 0, len(n.Fields))
+	for name := range n.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := n.Fields[name]
+		typ, ok := fieldType(f.Type)
+		if !ok {
+			continue
+		}
+		// graph do not record nullability
+		s.Fields = append(s.Fields, &miniquery.SchemaField{Name: name, Column: f.Column, Type: typ, Nullable: true})
+	}
+	for name, e := range n.Edges {
+		if s.Relations == nil {
+			s.Relations = map[string]*miniquery.Schema{}
+		}
+		s.Relations[name] = schemaOf(e.To, seen)
+		if e.Spec != nil && (e.Spec.Rel == sqlgraph.O2M || e.Spec.Rel == sqlgraph.M2M) {
+			s.ToMany = append(s.ToMany, name)
+		}
+	}
+	sort.Strings(s.ToMany)
+	return s
+}
+
+func fieldType(t field.Type) (miniquery.FieldType, bool) {
+	switch {
+	case t == field.TypeBool:
+		return miniquery.BoolFieldType, true
+	case t == field.TypeTime:
+		return miniquery.TimeFieldType, true
+	case t == field.TypeUUID:
+		return miniquery.UUIDFieldType, true
+	case t == field.TypeJSON:
+		return miniquery.JSONFieldType, true
+	case t == field.TypeString, t == field.TypeEnum, t == field.TypeOther:
+		return miniquery.StringFieldType, true
+	case t.Float():
+		return miniquery.FloatFieldType, true
+	case t.Integer():
+		return miniquery.IntFieldType, true
+	}
+	return "", false
+}
@@ -0,0 +1,151 @@
+package entmq_test
+
+import (
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/go-miniquery/entmq"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+func newTestGraph() *sqlgraph.Schema {
+	graph := &sqlgraph.Schema{
+		Nodes: []*sqlgraph.Node{
+			{
+				NodeSpec: sqlgraph.NodeSpec{
+					Table:   "users",
+					Columns: []string{"id", "username", "age", "created_at", "display_name"},
+					ID:      &sqlgraph.FieldSpec{Type: field.TypeInt, Column: "id"},
+				},
+				Type: "User",
+				Fields: map[string]*sqlgraph.FieldSpec{
+					"username":   {Type: field.TypeString, Column: "username"},
+					"age":        {Type: field.TypeInt, Column: "age"},
+					"created_at": {Type: field.TypeTime, Column: "created_at"},
+					"full_name":  {Type: field.TypeString, Column: "display_name"},
+				},
+			},
+			{
+				NodeSpec: sqlgraph.NodeSpec{
+					Table:   "orders",
+					Columns: []string{"id", "total", "user_id"},
+					ID:      &sqlgraph.FieldSpec{Type: field.TypeUUID, Column: "id"},
+				},
+				Type: "Order",
+				Fields: map[string]*sqlgraph.FieldSpec{
+					"total": {Type: field.TypeFloat64, Column: "total"},
+				},
+			},
+			{
+				NodeSpec: sqlgraph.NodeSpec{
+					Table:   "companies",
+					Columns: []string{"id", "name"},
+					ID:      &sqlgraph.FieldSpec{Type: field.TypeInt, Column: "id"},
+				},
+				Type: "Company",
+				Fields: map[string]*sqlgraph.FieldSpec{
+					"name": {Type: field.TypeString, Column: "name"},
+				},
+			},
+		},
+	}
+	graph.MustAddE("orders", &sqlgraph.EdgeSpec{
+		Rel:     sqlgraph.O2M,
+		Table:   "orders",
+		Columns: []string{"user_id"},
+	}, "User", "Order")
+	graph.MustAddE("owner", &sqlgraph.EdgeSpec{
+		Rel:     sqlgraph.M2O,
+		Inverse: true,
+		Table:   "orders",
+		Columns: []string{"user_id"},
+	}, "Order", "User")
+	graph.MustAddE("company", &sqlgraph.EdgeSpec{
+		Rel:     sqlgraph.M2O,
+		Inverse: true,
+		Table:   "users",
+		Columns: []string{"company_id"},
+	}, "User", "Company")
+	return graph
+}
+
+func TestSchemaOf(t *testing.T) {
+	graph := newTestGraph()
+	s := entmq.SchemaOf(graph.Nodes[0])
+	f, err := s.Lookup([]string{"createdAt"})
+	assert.NoError(t, err)
+	assert.Equal(t, miniquery.TimeFieldType, f.Type)
+	f, err = s.Lookup([]string{"orders", "id"})
+	assert.NoError(t, err)
+	assert.Equal(t, miniquery.UUIDFieldType, f.Type)
+
+	for _, test := range []struct {
+		Q    string
+		E    string
+		Args []interface{}
+		Err  bool
+	}{
+		{Q: `age > '18'`, E: `"age" > $1`, Args: []interface{}{18}},
+		{Q: `age > 'abc'`, Err: true},
+		{Q: `name = 'wener'`, Err: true},
+	} {
+		b := &entmq.MiniQLToEntSQLBuilder{QueryString: test.Q, Node: graph.Nodes[0], DisableTypeCasting: true, Check: true}
+		b.SetDialect(dialect.Postgres)
+		q, args := b.Query()
+		if test.Err {
+			assert.Error(t, b.Err(), test.Q)
+			continue
+		}
+		assert.NoError(t, b.Err())
+		assert.Equal(t, test.E, q)
+		assert.EqualValues(t, test.Args, args)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	graph := newTestGraph()
+	d := entmq.Describe(graph.Nodes[0], &miniquery.Policy{Deny: []string{"password"}})
+	assert.Equal(t, entmq.Functions, d.Functions)
+	fields := map[string]miniquery.DescriptionField{}
+	for _, v := range d.Fields {
+		fields[v.Name] = v
+	}
+	assert.Equal(t, miniquery.TimeFieldType, fields["created_at"].Type)
+	assert.True(t, fields["orders.id"].ToMany)
+	assert.Contains(t, fields["age"].Operators, miniquery.OpBetween)
+}
+
+func TestEntSQLPolicy(t *testing.T) {
+	graph := newTestGraph()
+	policy := &miniquery.Policy{Deny: []string{"orders"}}
+	for _, q := range []string{`has_edge(orders)`, `orders.total > 1`} {
+		b := &entmq.MiniQLToEntSQLBuilder{QueryString: q, Node: graph.Nodes[0], Policy: policy}
+		b.SetDialect(dialect.Postgres)
+		b.Query()
+		assert.Error(t, b.Err(), q)
+	}
+
+	// denied by field name or column
+	for _, deny := range []string{"full_name", "display_name"} {
+		policy := &miniquery.Policy{Deny: []string{deny}}
+		for _, q := range []string{`full_name = 'x'`, `display_name = 'x'`} {
+			b := &entmq.MiniQLToEntSQLBuilder{QueryString: q, Node: graph.Nodes[0], Policy: policy}
+			b.SetDialect(dialect.Postgres)
+			b.Query()
+			assert.ErrorIs(t, b.Err(), miniquery.ErrUnknownField, "%s %s", deny, q)
+		}
+		ob := &entmq.EntSQLOrderBuilder{Order: "display_name", Node: graph.Nodes[0], Policy: policy}
+		_, err := ob.Build()
+		assert.ErrorIs(t, err, miniquery.ErrUnknownField, deny)
+	}
+
+	b := &entmq.MiniQLToEntSQLBuilder{QueryString: `age > :min`, Node: graph.Nodes[0], Policy: policy, Params: map[string]interface{}{"min": 18}, DisableTypeCasting: true}
+	b.SetDialect(dialect.Postgres)
+	q, args := b.Query()
+	assert.NoError(t, b.Err())
+	assert.Equal(t, `"age" > $1`, q)
+	assert.Equal(t, []interface{}{18}, args)
+}
@@ -0,0 +1,268 @@
+package gormq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/go-miniquery/miniquery"
+	"gorm.io/gorm"
+)
+
+type Country struct {
+	ID   uint `gorm:"primarykey"`
+	Code string
+}
+
+type Company struct {
+	ID        uint `gorm:"primarykey"`
+	Name      string
+	CountryID uint
+	Country   *Country
+}
+
+type Customer struct {
+	ID        uint `gorm:"primarykey"`
+	Name      string
+	CompanyID uint
+	Company   *Company
+	Orders    []CustomerOrder `gorm:"foreignKey:CustomerID"`
+	Tags      []CustomerTag   `gorm:"many2many:customer_tag_links"`
+}
+
+type CustomerTag struct {
+	ID   uint `gorm:"primarykey"`
+	Name string
+}
+
+type CustomerOrder struct {
+	ID         uint `gorm:"primarykey"`
+	Total      int
+	CustomerID uint
+	Customer   *Customer
+}
+
+func TestNestedJoin(t *testing.T) {
+	db := getPreparedDB(t)
+	assert.NoError(t, db.AutoMigrate(Country{}, Company{}, Customer{}, CustomerOrder{}))
+	db.Where("1 = 1").Delete(&CustomerOrder{})
+	cn := &Country{Code: "CN"}
+	us := &Country{Code: "US"}
+	db.Create(&CustomerOrder{Total: 10, Customer: &Customer{Name: "a", Company: &Company{Name: "wener", Country: cn}}})
+	db.Create(&CustomerOrder{Total: 20, Customer: &Customer{Name: "b", Company: &Company{Name: "acme", Country: us}}})
+
+	for _, test := range []struct {
+		Q     string
+		Order string
+		E     string
+		Err   miniquery.ErrorCode
+	}{
+		{
+			Q: `Customer.Company.Country.code = 'CN'`,
+			E: "SELECT `customer_orders`.`id`,`customer_orders`.`total`,`customer_orders`.`customer_id`," +
+				"`Customer`.`id` AS `Customer__id`,`Customer`.`name` AS `Customer__name`,`Customer`.`company_id` AS `Customer__company_id`," +
+				"`Customer__Company`.`id` AS `Customer__Company__id`,`Customer__Company`.`name` AS `Customer__Company__name`,`Customer__Company`.`country_id` AS `Customer__Company__country_id`," +
+				"`Customer__Company__Country`.`id` AS `Customer__Company__Country__id`,`Customer__Company__Country`.`code` AS `Customer__Company__Country__code` " +
+				"FROM `customer_orders` " +
+				"LEFT JOIN `customers` `Customer` ON `customer_orders`.`customer_id` = `Customer`.`id` " +
+				"LEFT JOIN `companies` `Customer__Company` ON `Customer`.`company_id` = `Customer__Company`.`id` " +
+				"LEFT JOIN `countries` `Customer__Company__Country` ON `Customer__Company`.`country_id` = `Customer__Company__Country`.`id` " +
+				"WHERE `Customer__Company__Country`.`code` = ?",
+		},
+		{
+			Q:     `customer.name = 'a' or Customer.Company.name like 'w%'`,
+			Order: `Customer.Company.Country.code desc`,
+			E: "FROM `customer_orders` " +
+				"LEFT JOIN `customers` `Customer` ON `customer_orders`.`customer_id` = `Customer`.`id` " +
+				"LEFT JOIN `companies` `Customer__Company` ON `Customer`.`company_id` = `Customer__Company`.`id` " +
+				"LEFT JOIN `countries` `Customer__Company__Country` ON `Customer__Company`.`country_id` = `Customer__Company__Country`.`id` " +
+				"WHERE (`Customer`.`name` = ? OR `Customer__Company`.`name` LIKE ?) ORDER BY `Customer__Company__Country`.`code` DESC",
+		},
+		{Q: `Customer.Compny.name = 'x'`, Err: miniquery.ErrUnknownRelation},
+		{Q: `Customer.Company.nam = 'x'`, Err: miniquery.ErrUnknownField},
+	} {
+		t.Run(test.Q, func(t *testing.T) {
+			var orders []CustomerOrder
+			query := db.Model(CustomerOrder{}).Session(&gorm.Session{DryRun: true}).Scopes(MiniQuery{Query: []string{test.Q}, Order: test.Order}.Scope).Find(&orders)
+			if test.Err != "" {
+				assert.ErrorIs(t, query.Error, test.Err)
+				return
+			}
+			assert.NoError(t, query.Error)
+			assert.Contains(t, query.Statement.SQL.String(), test.E)
+		})
+	}
+
+	var orders []CustomerOrder
+	assert.NoError(t, db.Model(CustomerOrder{}).Scopes(MiniQuery{Query: []string{`Customer.Company.Country.code = 'US'`}, Check: true}.Scope).Find(&orders).Error)
+	if assert.Len(t, orders, 1) {
+		assert.Equal(t, 20, orders[0].Total)
+		assert.Equal(t, "acme", orders[0].Customer.Company.Name)
+		assert.Equal(t, "US", orders[0].Customer.Company.Country.Code)
+	}
+}
+
+func TestExists(t *testing.T) {
+	db := getPreparedDB(t)
+	assert.NoError(t, db.AutoMigrate(Country{}, Company{}, Customer{}, CustomerOrder{}, CustomerTag{}))
+	db.Where("1 = 1").Delete(&CustomerOrder{})
+	db.Where("1 = 1").Delete(&Customer{})
+	db.Exec("DELETE FROM customer_tag_links")
+	vip := &CustomerTag{Name: "vip"}
+	db.Create(&Customer{Name: "a", Orders: []CustomerOrder{{Total: 10}, {Total: 200}, {Total: 300}}, Tags: []CustomerTag{*vip}})
+	db.Create(&Customer{Name: "b", Orders: []CustomerOrder{{Total: 20}}, Company: &Company{Name: "acme", Country: &Country{Code: "US"}}})
+	db.Create(&Customer{Name: "c"})
+
+	for _, test := range []struct {
+		Q     string
+		E     string
+		Names []string
+		Err   miniquery.ErrorCode
+	}{
+		{
+			Q:     `Orders.total > 100`,
+			E:     "WHERE EXISTS (SELECT 1 FROM `customer_orders` `customers__Orders` WHERE `customers`.`id` = `customers__Orders`.`customer_id` AND `customers__Orders`.`total` > ?)",
+			Names: []string{"a"},
+		},
+		{
+			Q:     `100 < Orders.total`,
+			E:     "WHERE EXISTS (SELECT 1 FROM `customer_orders` `customers__Orders` WHERE `customers`.`id` = `customers__Orders`.`customer_id` AND ? < `customers__Orders`.`total`)",
+			Names: []string{"a"},
+		},
+		{Q: `has(Orders)`, E: "WHERE EXISTS (SELECT 1 FROM `customer_orders` `customers__Orders` WHERE `customers`.`id` = `customers__Orders`.`customer_id`)", Names: []string{"a", "b"}},
+		{Q: `not has(orders, total > 15)`, Names: []string{"c"}},
+		{Q: `has_edge(Orders, total < 15 or total = 20) and name != 'a'`, Names: []string{"b"}},
+		{
+			Q: `Tags.name = 'vip'`,
+			E: "WHERE EXISTS (SELECT 1 FROM `customer_tags` `customers__Tags` JOIN `customer_tag_links` ON `customer_tag_links`.`customer_tag_id` = `customers__Tags`.`id` " +
+				"WHERE `customer_tag_links`.`customer_id` = `customers`.`id` AND `customers__Tags`.`name` = ?)",
+			Names: []string{"a"},
+		},
+		{Q: `has(Tags) or Company.Country.code = 'US'`, Names: []string{"a", "b"}},
+		{Q: `has(Company, Country.code = 'US')`, Names: []string{"b"}},
+		// root columns are qualified, joined and related tables have the same column
+		{Q: `Company.name = 'acme' and id > 0`, Names: []string{"b"}},
+		{Q: `has(Orders, id > 0 and total > 100) and Company.id is null and id > 0`, Names: []string{"a"}},
+		{Q: `has(Ordrs)`, Err: miniquery.ErrUnknownRelation},
+		{Q: `has('Orders')`, Err: miniquery.ErrInvalidEdgeArgs},
+		{Q: `Orders.missing > 1`, Err: miniquery.ErrUnknownField},
+	} {
+		t.Run(test.Q, func(t *testing.T) {
+			var customers []Customer
+			dry := db.Model(Customer{}).Session(&gorm.Session{DryRun: true}).Scopes(MiniQuery{Query: []string{test.Q}}.Scope).Find(&customers)
+			if test.Err != "" {
+				assert.ErrorIs(t, dry.Error, test.Err)
+				return
+			}
+			assert.NoError(t, dry.Error)
+			assert.Contains(t, dry.Statement.SQL.String(), test.E)
+
+			assert.NoError(t, db.Model(Customer{}).Scopes(MiniQuery{Query: []string{test.Q}, Check: true}.Scope).Order("customers.name").Find(&customers).Error)
+			var names []string
+			for _, v := range customers {
+				names = append(names, v.Name)
+			}
+			assert.Equal(t, test.Names, names)
+
+			// parity with eval on loaded relations
+			var all []Customer
+			assert.NoError(t, db.Preload("Orders").Preload("Tags").Preload("Company.Country").Order("name").Find(&all).Error)
+			node, err := miniquery.Parse(test.Q)
+			assert.NoError(t, err)
+			names = nil
+			for _, v := range all {
+				ok, err := miniquery.Eval(node, v)
+				assert.NoError(t, err)
+				if ok {
+					names = append(names, v.Name)
+				}
+			}
+			assert.Equal(t, test.Names, names, "eval")
+		})
+	}
+
+	// to-many from a joined relation
+	var orders []CustomerOrder
+	assert.NoError(t, db.Model(CustomerOrder{}).Scopes(ApplyMiniQuery(`Customer.Tags.name = 'vip' and total > 100`)).Find(&orders).Error)
+	assert.Len(t, orders, 2)
+}
+
+type Team struct {
+	ID        uint `gorm:"primarykey"`
+	Name      string
+	DeletedAt gorm.DeletedAt
+}
+
+type Member struct {
+	ID     uint `gorm:"primarykey"`
+	Name   string
+	TeamID uint
+	Team   *Team
+	Tasks  []MemberTask
+}
+
+type MemberTask struct {
+	ID        uint `gorm:"primarykey"`
+	Title     string
+	MemberID  uint
+	DeletedAt gorm.DeletedAt
+}
+
+func TestSoftDelete(t *testing.T) {
+	db := getPreparedDB(t)
+	assert.NoError(t, db.AutoMigrate(Team{}, Member{}, MemberTask{}))
+	db.Unscoped().Where("1 = 1").Delete(&MemberTask{})
+	db.Where("1 = 1").Delete(&Member{})
+	db.Unscoped().Where("1 = 1").Delete(&Team{})
+	db.Create(&Member{Name: "a", Team: &Team{Name: "dev"}, Tasks: []MemberTask{{Title: "x"}}})
+	b := &Member{Name: "b", Team: &Team{Name: "ops"}, Tasks: []MemberTask{{Title: "y"}}}
+	db.Create(b)
+	db.Delete(b.Team)
+	db.Delete(&b.Tasks[0])
+
+	for _, test := range []struct {
+		Q        string
+		Unscoped bool
+		E        string
+		Names    []string
+	}{
+		{
+			Q:     `Team.name = 'ops'`,
+			E:     "LEFT JOIN `teams` `Team` ON `members`.`team_id` = `Team`.`id` AND `Team`.`deleted_at` IS NULL WHERE `Team`.`name` = ?",
+			Names: nil,
+		},
+		{
+			Q:        `Team.name = 'ops'`,
+			Unscoped: true,
+			E:        "FROM `members` LEFT JOIN `teams` `Team` ON `members`.`team_id` = `Team`.`id` WHERE `Team`.`name` = ?",
+			Names:    []string{"b"},
+		},
+		{
+			Q:     `Tasks.title != 'z'`,
+			E:     "WHERE EXISTS (SELECT 1 FROM `member_tasks` `members__Tasks` WHERE `members`.`id` = `members__Tasks`.`member_id` AND `members__Tasks`.`title` <> ? AND `members__Tasks`.`deleted_at` IS NULL)",
+			Names: []string{"a"},
+		},
+		{Q: `has(Tasks) and Team.name is not null`, Unscoped: true, Names: []string{"a", "b"}},
+	} {
+		t.Run(test.Q, func(t *testing.T) {
+			q := MiniQuery{Query: []string{test.Q}, Unscoped: test.Unscoped}
+			var members []Member
+			dry := db.Model(Member{}).Session(&gorm.Session{DryRun: true}).Scopes(q.Scope).Find(&members)
+			assert.NoError(t, dry.Error)
+			assert.Contains(t, dry.Statement.SQL.String(), test.E)
+
+			assert.NoError(t, db.Model(Member{}).Scopes(q.Scope).Order("members.name").Find(&members).Error)
+			var names []string
+			for _, v := range members {
+				names = append(names, v.Name)
+			}
+			assert.Equal(t, test.Names, names)
+		})
+	}
+
+	// joined by previous scope is reused
+	var members []Member
+	dry := db.Model(Member{}).Session(&gorm.Session{DryRun: true}).Scopes(MiniQuery{Query: []string{`Team.name = 'dev'`}, Order: `Team.name`, Unscoped: true}.Scope).Find(&members)
+	assert.NoError(t, dry.Error)
+	assert.Equal(t, 1, strings.Count(dry.Statement.SQL.String(), "JOIN"))
+}
@@ -0,0 +1,82 @@
+package gormq
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/wenerme/go-miniquery/miniquery"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApplyList apply list request, return list query and total count query of the filter
+//
+//	query, count := gormq.ApplyList(db.Model(User{}), req)
+//	err = count.Count(&total).Error
+//	err = query.Find(&users).Error
+func ApplyList(db *gorm.DB, req *miniquery.ListRequest) (query *gorm.DB, count *gorm.DB) {
+	return MiniQuery{}.List(db, req)
+}
+
+// List apply list request with the options of q, request query is joined with q.Query,
+// count query ignore order, cursor, limit and offset
+func (q MiniQuery) List(db *gorm.DB, req *miniquery.ListRequest) (query *gorm.DB, count *gorm.DB) {
+	db = db.Session(&gorm.Session{})
+
+	filter := q
+	filter.Query = append(slices.Clone(q.Query), req.Query)
+	filter.Order = ""
+	filter.Cursor = ""
+	count = db.Scopes(filter.Scope)
+
+	list := filter
+	list.Order = req.Order
+	list.Cursor = req.Cursor
+	query = db.Scopes(list.Scope)
+	if len(req.Fields) != 0 {
+		query = q.selectFields(query, req.Fields)
+	}
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit)
+	}
+	if req.Offset > 0 {
+		query = query.Offset(req.Offset)
+	}
+	return query, count
+}
+
+// selectFields select columns of fields, fields are resolved like filter
+func (q MiniQuery) selectFields(db *gorm.DB, fields []string) *gorm.DB {
+	schema, err := GetOrParseSchema(db)
+	if err != nil {
+		_ = db.AddError(err)
+		return db
+	}
+	policy := q.policy(db)
+	columns := make([]interface{}, 0, len(fields))
+	for _, f := range fields {
+		names := []string{f}
+		if policy != nil && !policy.AllowField(names, "") {
+			_ = db.AddError(miniquery.NewError(miniquery.ErrUnknownField).WithField(f))
+			return db
+		}
+		if q.Resolver != nil {
+			if names, err = q.Resolver.ResolveField(names); err != nil {
+				_ = db.AddError(err)
+				return db
+			}
+		}
+		if len(names) != 1 {
+			_ = db.AddError(miniquery.NewError(miniquery.ErrUnsupportedReference).WithField(names...))
+			return db
+		}
+		name, ok := getDBName(schema, names[0])
+		if !ok {
+			_ = db.AddError(policy.Redact(miniquery.NewError(miniquery.ErrUnknownField).WithField(f).WithSuggestions(f, schema.DBNames)))
+			return db
+		}
+		// qualified as relations may be joined by filter
+		columns = append(columns, clause.Column{Table: clause.CurrentTable, Name: name})
+	}
+	return db.Select(strings.TrimSuffix(strings.Repeat("?,", len(columns)), ","), columns...)
+}
@@ -1,22 +1,61 @@
 package gormq
 
 import (
-	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
-	"github.com/pkg/errors"
 	"github.com/wenerme/go-miniquery/miniquery"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils"
 )
 
 // MiniQuery Wrap multi miniquery in one scope, will join query by and
 type MiniQuery struct {
 	Query []string
+	// Check type check the query and coerce values by schema derived from the model
+	Check bool
+	// Policy restrict filterable fields and operators, applied to public field names,
+	// names are canonicalized by schema of the model unless mapped by Resolver
+	Policy *miniquery.Policy
+	// Resolver map public field names before resolving columns
+	Resolver miniquery.FieldResolver
+	// Virtuals virtual fields expanded before building
+	Virtuals *miniquery.Virtuals
+	// Params bind :name parameters of query and virtual fields
+	Params map[string]interface{}
+	// Order order string like `createdAt desc, Profile.age asc nulls last`, see ApplyOrder
+	Order string
+	// Cursor select rows after the cursor of Order, see miniquery.NewCursor
+	Cursor string
+	// Unscoped include soft deleted rows of related models in relation filters,
+	// other query clauses of related models are still applied
+	Unscoped bool
 }
 
 func (q MiniQuery) Scope(db *gorm.DB) *gorm.DB {
-	return WireMiniQuery(db, miniquery.Join(q.Query))
+	q.Policy = q.policy(db)
+	db = q.wire(db, miniquery.Join(q.Query))
+	if q.Order != "" && db.Error == nil {
+		db = q.order(db, q.Order)
+	}
+	return db
+}
+
+// policy canonicalize field names of q.Policy by schema of the model, so a field can not bypass the policy by its column name,
+// public names mapped by Resolver are matched as is
+func (q MiniQuery) policy(db *gorm.DB) *miniquery.Policy {
+	if q.Policy == nil || q.Resolver != nil {
+		return q.Policy
+	}
+	st, err := GetOrParseSchema(db)
+	if err != nil {
+		return q.Policy
+	}
+	return q.Policy.WithSchema(SchemaOf(st))
 }
 
 func GetOrParseSchema(db *gorm.DB) (schema *schema.Schema, err error) {
@@ -27,7 +66,7 @@ func GetOrParseSchema(db *gorm.DB) (schema *schema.Schema, err error) {
 				return
 			}
 		} else {
-			return nil, fmt.Errorf("missing model")
+			return nil, miniquery.NewError(miniquery.ErrMissingModel)
 		}
 	}
 	return stat.Schema, nil
@@ -41,12 +80,41 @@ func ApplyMiniQuery(query string) func(db *gorm.DB) *gorm.DB {
 }
 
 func WireMiniQuery(db *gorm.DB, query string) *gorm.DB {
-	if query == "" {
+	return MiniQuery{}.wire(db, query)
+}
+
+func (q MiniQuery) wire(db *gorm.DB, query string) *gorm.DB {
+	if query == "" && q.Cursor == "" {
 		return db
 	}
-	ast, err := miniquery.Parse(query)
-	if err != nil {
-		_ = db.AddError(fmt.Errorf("invalid query syntax: %v", err))
+	var ast *miniquery.Node
+	var err error
+	if query != "" {
+		if ast, err = miniquery.Parse(query); err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+	}
+	if q.Cursor != "" {
+		keyset, err := q.keyset()
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		ast = miniquery.And(ast, keyset)
+	}
+
+	if err = q.Policy.Enforce(ast); err != nil {
+		_ = db.AddError(err)
+		return db
+	}
+	// resolve public names before the definitions of virtual fields are expanded
+	if err = miniquery.Resolve(ast, q.Virtuals.Resolver(q.Resolver)); err != nil {
+		_ = db.AddError(err)
+		return db
+	}
+	if ast, err = miniquery.Expand(ast, q.Virtuals, q.Params); err != nil {
+		_ = db.AddError(err)
 		return db
 	}
 
@@ -55,174 +123,553 @@ func WireMiniQuery(db *gorm.DB, query string) *gorm.DB {
 		_ = db.AddError(err)
 		return db
 	}
-	var vals []interface{}
-	buf := &strings.Builder{}
-	joined := map[string][]string{}
-	quote := func(builder *strings.Builder, name string) {
-		db.QuoteTo(builder, name)
-	}
-	qb := &queryBuilder{
-		buf: buf,
-		addValue: func(i interface{}) {
-			vals = append(vals, i)
-		},
-		mapName: func(s string) (string, error) {
-			n, ok := getDBName(schema, s)
-			if !ok {
-				return s, fmt.Errorf("field not found: %q", s)
-			}
-			return n, nil
-		},
-		join: func(s string, fieldName string) (string, error) {
-			r := schema.Relationships.Relations[s]
-			if r == nil {
-				return "", fmt.Errorf("relation not found: %q", s)
-			}
-
-			var name string
-			var ok bool
-			if name, ok = getDBName(r.FieldSchema, fieldName); !ok {
-				return "", fmt.Errorf("relation field not found: %q.%q", s, fieldName)
-			}
-			if _, ok := joined[s]; !ok {
-				db = db.Joins(s)
-			}
-			joined[s] = append(joined[s], name)
-			// gorm Join logic name
-			// fixme quote
-			return s + "." + name, nil
-		},
-		quote: quote,
-	}
-	err = qb.visit(ast)
+	if q.Check {
+		if err = miniquery.Check(ast, SchemaOf(schema)); err != nil {
+			_ = db.AddError(q.Policy.Redact(err))
+			return db
+		}
+	}
+	qb := newQueryBuilder(db, schema)
+	qb.unscoped = q.Unscoped
+	expr, err := qb.condition(ast)
+	db = qb.db
 	if err != nil {
-		_ = db.AddError(err)
+		_ = db.AddError(q.Policy.Redact(err))
 	} else {
-		db = db.Where(buf.String(), vals...)
+		db = db.Where(expr)
 	}
 	return db
 }
 
 type queryBuilder struct {
-	buf      *strings.Builder
-	addValue func(interface{})
+	db     *gorm.DB
+	schema *schema.Schema
+	table  string // table or alias of schema
+	// unscoped join and match related rows without soft delete
+	unscoped bool
 	mapName  func(s string) (string, error)
-	quote    func(builder *strings.Builder, name string)
-	join     func(s string, f string) (string, error)
+	join     func(names []string) (interface{}, error) // column or json extraction of reference
 }
 
-func (qb *queryBuilder) visitReference(node *miniquery.Node) (err error) {
-	if len(node.Names) != 2 {
-		return errors.New("only support join one level")
+// newQueryBuilder create builder resolve columns of the model, relations are joined to qb.db once
+func newQueryBuilder(db *gorm.DB, st *schema.Schema) *queryBuilder {
+	qb := &queryBuilder{db: db, schema: st, table: db.Statement.Table}
+	qb.mapName = func(s string) (string, error) {
+		n, ok := getDBName(st, s)
+		if !ok {
+			return s, miniquery.NewError(miniquery.ErrUnknownField).WithField(s).WithSuggestions(s, st.DBNames)
+		}
+		return n, nil
 	}
-	name, err := qb.join(node.Names[0], node.Names[1])
-	if err != nil {
-		return err
+	qb.join = func(names []string) (interface{}, error) {
+		cur := st
+		var path []string
+		var rels []*schema.Relationship
+		var f *schema.Field
+		var jsonPath []string
+		for i, s := range names {
+			if i < len(names)-1 {
+				if r := getRelation(cur, s); r != nil {
+					if r.Type == schema.HasMany || r.Type == schema.Many2Many {
+						return nil, miniquery.NewError(miniquery.ErrUnsupportedReference).WithField(names...)
+					}
+					path = append(path, r.Name)
+					rels = append(rels, r)
+					cur = r.FieldSchema
+					continue
+				}
+			}
+			if f, jsonPath = lookupField(cur, names[i:]); f != nil {
+				break
+			}
+			if i < len(names)-1 && !isEmbedded(cur, s) {
+				return nil, miniquery.NewError(miniquery.ErrUnknownRelation).WithField(names[:i+1]...).WithSuggestions(s, relationNames(cur))
+			}
+			return nil, miniquery.NewError(miniquery.ErrUnknownField).WithField(names...).WithSuggestions(s, cur.DBNames)
+		}
+		qb.joinRelations(rels)
+		column := clause.Column{Table: clause.CurrentTable, Name: f.DBName}
+		if len(path) != 0 {
+			column.Table = utils.JoinNestedRelationNames(path)
+		}
+		if len(jsonPath) != 0 {
+			return qb.jsonExtract(column, jsonPath), nil
+		}
+		return column, nil
 	}
-	quote := qb.quote
-	buf := qb.buf
-	quote(buf, name)
-	return
+	return qb
 }
 
-func (qb *queryBuilder) visitIdentifier(node *miniquery.Node) (err error) {
-	buf := qb.buf
-	mapName := qb.mapName
-	quote := qb.quote
-	name := node.Name
-	/* extension to support custom virtual column */
-	// switch name {
-	// case "owned":
-	// 	buf.WriteString("(owner_id is not null)")
-	// 	return
-	// case "unowned":
-	// 	buf.WriteString("(owner_id is null)")
-	// 	return
-	// }
-	name, err = mapName(name)
-	if err != nil {
-		return err
+// lookupField find field by names, fields of embedded struct are matched by path like Address.City,
+// names after json field are returned as json path like Settings.theme
+func lookupField(st *schema.Schema, names []string) (*schema.Field, []string) {
+	for n := len(names); n > 0; n-- {
+		f := findField(st, names[:n])
+		if f == nil {
+			continue
+		}
+		if typ, _ := fieldType(f); n < len(names) && typ != miniquery.JSONFieldType {
+			return nil, nil
+		}
+		return f, names[n:]
 	}
-	quote(buf, name)
-	return
+	return nil, nil
 }
 
-func (qb *queryBuilder) visit(node *miniquery.Node) (err error) {
-	buf := qb.buf
-	addValue := qb.addValue
-	visit := qb.visit
+func findField(st *schema.Schema, names []string) *schema.Field {
+	if len(names) == 1 {
+		if name, ok := getDBName(st, names[0]); ok && name != "" {
+			return st.FieldsByDBName[name]
+		}
+		return nil
+	}
+	path := strings.Join(names, ".")
+	for _, f := range st.Fields {
+		if f.DBName != "" && strings.EqualFold(strings.Join(f.EmbeddedBindNames, "."), path) {
+			return f
+		}
+	}
+	return nil
+}
+
+// isEmbedded check whether name is an embedded struct field
+func isEmbedded(st *schema.Schema, name string) bool {
+	for _, f := range st.Fields {
+		if len(f.EmbeddedBindNames) > 1 && strings.EqualFold(f.EmbeddedBindNames[0], name) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonExtract extract value of json path from column by dialect
+func (qb *queryBuilder) jsonExtract(column clause.Column, path []string) clause.Expression {
+	if qb.db.Dialector.Name() == "postgres" {
+		return clause.Expr{SQL: "? #>> ?", Vars: []interface{}{column, "{" + strings.Join(path, ",") + "}"}}
+	}
+	p := &strings.Builder{}
+	p.WriteString("$")
+	for _, v := range path {
+		p.WriteString(".")
+		p.WriteString(strconv.Quote(v))
+	}
+	switch qb.db.Dialector.Name() {
+	case "mysql":
+		return clause.Expr{SQL: "JSON_UNQUOTE(JSON_EXTRACT(?, ?))", Vars: []interface{}{column, p.String()}}
+	case "sqlserver":
+		return clause.Expr{SQL: "JSON_VALUE(?, ?)", Vars: []interface{}{column, p.String()}}
+	}
+	return clause.Expr{SQL: "JSON_EXTRACT(?, ?)", Vars: []interface{}{column, p.String()}}
+}
+
+// joinRelations join relations of path once, shared prefixes are reused with alias like Profile__Address.
+// gorm join add query clauses of the related model like soft delete to join condition,
+// unscoped relations are joined with the same alias and condition, query clauses are built unscoped.
+func (qb *queryBuilder) joinRelations(rels []*schema.Relationship) {
+	if len(rels) == 0 {
+		return
+	}
+	names := make([]string, len(rels))
+	for i, r := range rels {
+		names[i] = r.Name
+	}
+	if !qb.unscoped {
+		if !isJoined(qb.db, names) {
+			qb.db = qb.db.Joins(strings.Join(names, "."))
+		}
+		return
+	}
+	parent := clause.CurrentTable
+	for i, r := range rels {
+		alias := utils.JoinNestedRelationNames(names[:i+1])
+		if !isJoined(qb.db, names[:i+1]) {
+			on := relationOn(r, parent, alias)
+			if expr := unscopedQueryClauses(qb.db, r.FieldSchema, alias); expr != nil {
+				on = append(on, expr)
+			}
+			qb.db = qb.db.Joins("LEFT JOIN ? ON ?", clause.Table{Name: r.FieldSchema.Table, Alias: alias}, clause.And(on...))
+		}
+		parent = alias
+	}
+}
 
+// unscopedQueryClauses build query clauses of schema for table alias without soft delete
+func unscopedQueryClauses(db *gorm.DB, st *schema.Schema, alias string) clause.Expression {
+	// soft delete check Unscoped of the statement of DB
+	stmt := &gorm.Statement{Table: alias, DB: db.Session(&gorm.Session{NewDB: true}).Unscoped(), Clauses: map[string]clause.Clause{}}
+	for _, c := range st.QueryClauses {
+		stmt.AddClause(c)
+	}
+	where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok || len(where.Exprs) == 0 {
+		return nil
+	}
+	where.Build(stmt)
+	// bind vars are written by dialect, restore placeholders
+	sql, vars := stmt.SQL.String(), stmt.Vars
+	for i, v := range vars {
+		bindvar := &strings.Builder{}
+		stmt.Vars = vars[:i+1]
+		db.Dialector.BindVarTo(bindvar, stmt, v)
+		sql = strings.Replace(sql, bindvar.String(), "?", 1)
+	}
+	return clause.Expr{SQL: sql, Vars: vars}
+}
+
+// isJoined check whether relation path already joined by previous scopes, by gorm relation join or unscoped join
+func isJoined(db *gorm.DB, names []string) bool {
+	name, alias := strings.Join(names, "."), utils.JoinNestedRelationNames(names)
+	for _, j := range db.Statement.Joins {
+		if j.Name == name {
+			return true
+		}
+		if len(j.Conds) != 0 {
+			if t, ok := j.Conds[0].(clause.Table); ok && t.Alias == alias {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// relationOn join condition of relation from parent to child table alias, same as gorm join
+func relationOn(r *schema.Relationship, parent, child string) []clause.Expression {
+	on := make([]clause.Expression, 0, len(r.References))
+	for _, ref := range r.References {
+		switch {
+		case ref.OwnPrimaryKey:
+			on = append(on, clause.Eq{Column: clause.Column{Table: parent, Name: ref.PrimaryKey.DBName}, Value: clause.Column{Table: child, Name: ref.ForeignKey.DBName}})
+		case ref.PrimaryValue == "":
+			on = append(on, clause.Eq{Column: clause.Column{Table: parent, Name: ref.ForeignKey.DBName}, Value: clause.Column{Table: child, Name: ref.PrimaryKey.DBName}})
+		default:
+			on = append(on, clause.Eq{Column: clause.Column{Table: child, Name: ref.ForeignKey.DBName}, Value: ref.PrimaryValue})
+		}
+	}
+	return on
+}
+
+// condition build condition of node as native gorm clause, so other plugins can inspect and rewrite it
+func (qb *queryBuilder) condition(node *miniquery.Node) (clause.Expression, error) {
+	if path, cond := qb.toMany(node); path != nil {
+		return qb.visitHas(path, cond)
+	}
 	switch node.Type {
-	case miniquery.OperationNodeType:
-		buf.WriteString(normalizeSQL(node.Operation))
-	case miniquery.ValueNodeType:
-		// 支持数组值
-		buf.WriteRune('?')
-		addValue(node.Value())
-	case miniquery.IdentifierNodeType:
-		err = qb.visitIdentifier(node)
-	case miniquery.ReferenceNodeType:
-		err = qb.visitReference(node)
 	case miniquery.ParenthesesExpressionType:
-		buf.WriteRune('(')
-		err = visit(node.Expression)
-		buf.WriteRune(')')
+		return qb.condition(node.Expression)
 	case miniquery.NotExpressionType:
-		buf.WriteString("not ")
-		err = visit(node.Expression)
-	case miniquery.FunctionExpressionType:
-		err = qb.visitFunction(node)
-	case miniquery.BetweenExpressionType:
-		err = visit(node.Left)
-		if err == nil {
-			buf.WriteRune(' ')
-			err = visit(node.Op)
+		expr, err := qb.condition(node.Expression)
+		if err != nil {
+			return nil, err
 		}
-		if err == nil {
-			buf.WriteRune(' ')
-			err = visit(node.Params[0])
+		// clause.Not negate each of and conditions
+		return clause.NotConditions{Exprs: []clause.Expression{expr}}, nil
+	case miniquery.LogicExpressionType:
+		l, err := qb.condition(node.Left)
+		if err != nil {
+			return nil, err
 		}
-		if err == nil {
-			buf.WriteString(" and ")
-			err = visit(node.Params[1])
+		r, err := qb.condition(node.Right)
+		if err != nil {
+			return nil, err
 		}
-	case miniquery.PredicatesExpressionType:
-		fallthrough
-	case miniquery.LogicExpressionType:
-		fallthrough
+		if node.Op.Operation == miniquery.OpOr {
+			return clause.OrConditions{Exprs: flatten[clause.OrConditions](l, r)}, nil
+		}
+		return clause.AndConditions{Exprs: flatten[clause.AndConditions](l, r)}, nil
 	case miniquery.CompareExpressionType:
-		err = visit(node.Left)
-		if err == nil {
-			buf.WriteRune(' ')
-			err = visit(node.Op)
+		return qb.compare(node)
+	case miniquery.BetweenExpressionType:
+		ops, err := qb.operands(node.Left, node.Params[0], node.Params[1])
+		if err != nil {
+			return nil, err
 		}
-		if err == nil && node.Right != nil {
-			buf.WriteRune(' ')
-			err = visit(node.Right)
+		return clause.Expr{SQL: "? " + strings.ToUpper(string(node.Op.Operation)) + " ? AND ?", Vars: ops}, nil
+	case miniquery.PredicatesExpressionType:
+		v, err := qb.operand(node.Left)
+		if err != nil {
+			return nil, err
 		}
-	default:
-		return errors.Errorf("invalid type %q", node.Type)
+		if c, ok := v.(clause.Column); ok {
+			switch node.Op.Operation {
+			case miniquery.OpIsNull:
+				return clause.Eq{Column: c, Value: nil}, nil
+			case miniquery.OpIsNotNull:
+				return clause.Neq{Column: c, Value: nil}, nil
+			}
+		}
+		return clause.Expr{SQL: "? " + strings.ToUpper(string(node.Op.Operation)), Vars: []interface{}{v}}, nil
+	case miniquery.FunctionExpressionType:
+		return qb.visitFunction(node)
+	case miniquery.ValueNodeType, miniquery.IdentifierNodeType, miniquery.ReferenceNodeType:
+		// bare value or field as condition
+		v, err := qb.operand(node)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Expr{SQL: "?", Vars: []interface{}{v}}, nil
 	}
-	return err
+	return nil, miniquery.NewError(miniquery.ErrInvalidNode).WithValue(string(node.Type))
 }
 
-func (qb *queryBuilder) visitFunction(node *miniquery.Node) (err error) {
-	buf := qb.buf
-	visit := qb.visit
+// flatten merge nested conditions of same logic, gorm wrap each nested one in parentheses
+func flatten[T clause.AndConditions | clause.OrConditions](exprs ...clause.Expression) []clause.Expression {
+	var out []clause.Expression
+	for _, expr := range exprs {
+		if v, ok := expr.(T); ok {
+			switch v := any(v).(type) {
+			case clause.AndConditions:
+				out = append(out, v.Exprs...)
+			case clause.OrConditions:
+				out = append(out, v.Exprs...)
+			}
+			continue
+		}
+		out = append(out, expr)
+	}
+	return out
+}
+
+func (qb *queryBuilder) compare(node *miniquery.Node) (clause.Expression, error) {
+	ops, err := qb.operands(node.Left, node.Right)
+	if err != nil {
+		return nil, err
+	}
+	l, r := ops[0], ops[1]
+	op := node.Op.Operation
+	if (op == miniquery.OpLike || op == miniquery.OpNotLike) && qb.escapeLike(r) {
+		return clause.Expr{SQL: "? " + normalizeSQL(string(op)) + " ? ESCAPE ?", Vars: []interface{}{l, r, `\`}}, nil
+	}
+	// native clause need column on the left, eq and neq of nil and array become is null and in
+	if c, ok := l.(clause.Column); ok {
+		switch op {
+		case miniquery.OpIn, miniquery.OpNotIn:
+			if node.Right.Type == miniquery.ValueNodeType {
+				values, ok := r.([]interface{})
+				if !ok {
+					values = []interface{}{r}
+				}
+				if op == miniquery.OpNotIn {
+					if len(values) == 0 {
+						// gorm render empty not in as is not null, which drops null rows
+						return clause.Expr{SQL: "1 = 1"}, nil
+					}
+					return clause.Not(clause.IN{Column: c, Values: values}), nil
+				}
+				return clause.IN{Column: c, Values: values}, nil
+			}
+		case miniquery.OpLike:
+			return clause.Like{Column: c, Value: r}, nil
+		case miniquery.OpNotLike:
+			return clause.Not(clause.Like{Column: c, Value: r}), nil
+		}
+		if _, isArray := r.([]interface{}); r != nil && !isArray {
+			switch op {
+			case miniquery.OpEQ:
+				return clause.Eq{Column: c, Value: r}, nil
+			case miniquery.OpNEQ:
+				return clause.Neq{Column: c, Value: r}, nil
+			case miniquery.OpGT:
+				return clause.Gt{Column: c, Value: r}, nil
+			case miniquery.OpGTE:
+				return clause.Gte{Column: c, Value: r}, nil
+			case miniquery.OpLT:
+				return clause.Lt{Column: c, Value: r}, nil
+			case miniquery.OpLTE:
+				return clause.Lte{Column: c, Value: r}, nil
+			}
+		}
+	}
+	return clause.Expr{SQL: "? " + normalizeSQL(string(op)) + " ?", Vars: []interface{}{l, r}}, nil
+}
+
+// escapeLike check whether like pattern need explicit \ escape character, which is default of mysql and postgres
+func (qb *queryBuilder) escapeLike(pattern interface{}) bool {
+	s, ok := pattern.(string)
+	if !ok || !strings.Contains(s, `\`) {
+		return false
+	}
+	switch qb.db.Dialector.Name() {
+	case "sqlite", "sqlserver":
+		return true
+	}
+	return false
+}
+
+func (qb *queryBuilder) operands(nodes ...*miniquery.Node) ([]interface{}, error) {
+	out := make([]interface{}, len(nodes))
+	for i, node := range nodes {
+		v, err := qb.operand(node)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// operand build value, clause.Column or expression of node
+func (qb *queryBuilder) operand(node *miniquery.Node) (interface{}, error) {
+	switch node.Type {
+	case miniquery.ValueNodeType:
+		// 支持数组值
+		return node.Value(), nil
+	case miniquery.IdentifierNodeType:
+		// virtual column is expanded by miniquery.Expand
+		name, err := qb.mapName(node.Name)
+		if err != nil {
+			return nil, err
+		}
+		// qualified as joined relations may have the same column, current table is the alias in subquery
+		return clause.Column{Table: clause.CurrentTable, Name: name}, nil
+	case miniquery.ReferenceNodeType:
+		return qb.join(node.Names)
+	}
+	return qb.condition(node)
+}
+
+func (qb *queryBuilder) visitFunction(node *miniquery.Node) (clause.Expression, error) {
 	switch node.Name {
 	case "date":
-		buf.WriteString(node.Name)
-		buf.WriteString("(")
-		for _, v := range node.Params {
-			if err = visit(v); err != nil {
-				return
-			}
+		args, err := qb.operands(node.Params...)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Expr{SQL: node.Name + "(" + strings.TrimSuffix(strings.Repeat("?,", len(args)), ",") + ")", Vars: args}, nil
+	case "now":
+		if len(node.Params) != 0 {
+			return nil, miniquery.NewError(miniquery.ErrInvalidArguments).WithFunction(node.Name).WithExpected("no arguments")
 		}
-		buf.WriteString(")")
+		return clause.Expr{SQL: "CURRENT_TIMESTAMP"}, nil
+	case "has", "has_edge":
+		params := node.Params
+		if len(params) == 0 || len(params) > 2 || params[0].Type != miniquery.IdentifierNodeType || len(params) == 2 && !params[1].IsExpression() {
+			return nil, miniquery.NewError(miniquery.ErrInvalidEdgeArgs).WithFunction(node.Name).WithValue(miniquery.Build(node))
+		}
+		var cond *miniquery.Node
+		if len(params) == 2 {
+			cond = params[1]
+		}
+		return qb.visitHas([]string{params[0].Name}, cond)
+	}
+	return nil, miniquery.NewError(miniquery.ErrUnsupportedFunction).WithFunction(node.Name).WithSuggestions(node.Name, Functions)
+}
+
+// Functions supported by gormq
+var Functions = []string{"date", "now", "has", "has_edge"}
+
+// toMany split condition on field of to-many relation like `Orders.total > 100` or `100 < Orders.total`
+// to relation path and condition on the related model
+func (qb *queryBuilder) toMany(node *miniquery.Node) ([]string, *miniquery.Node) {
+	switch node.Type {
+	case miniquery.CompareExpressionType, miniquery.BetweenExpressionType, miniquery.PredicatesExpressionType:
 	default:
-		err = fmt.Errorf("unsupported function: %q", node.Name)
+		return nil, nil
+	}
+	field, right := node.Left, false
+	if node.Type == miniquery.CompareExpressionType && field.Type != miniquery.ReferenceNodeType {
+		field, right = node.Right, true
+	}
+	if field == nil || field.Type != miniquery.ReferenceNodeType {
+		return nil, nil
+	}
+	names := field.Names
+	cur := qb.schema
+	for i, name := range names[:len(names)-1] {
+		r := getRelation(cur, name)
+		if r == nil {
+			return nil, nil
+		}
+		if r.Type == schema.HasMany || r.Type == schema.Many2Many {
+			rest := &miniquery.Node{Type: miniquery.ReferenceNodeType, Names: names[i+1:]}
+			if len(rest.Names) == 1 {
+				rest = &miniquery.Node{Type: miniquery.IdentifierNodeType, Name: rest.Names[0]}
+			}
+			cond := *node
+			if right {
+				cond.Right = rest
+			} else {
+				cond.Left = rest
+			}
+			return names[:i+1], &cond
+		}
+		cur = r.FieldSchema
+	}
+	return nil, nil
+}
+
+// visitHas build EXISTS subquery of relation path, the rest of path and cond apply to the related model,
+// to-many relations match parent once instead of joining rows
+func (qb *queryBuilder) visitHas(names []string, cond *miniquery.Node) (_ clause.Expression, err error) {
+	r := getRelation(qb.schema, names[0])
+	if r == nil {
+		return nil, miniquery.NewError(miniquery.ErrUnknownRelation).WithField(names[0]).WithSuggestions(names[0], relationNames(qb.schema))
+	}
+	alias := utils.NestedRelationName(qb.table, r.Name)
+	stmt := qb.db.Statement
+	sub := qb.db.Session(&gorm.Session{NewDB: true}).Model(reflect.New(r.FieldSchema.ModelType).Interface())
+	sub = sub.Table(stmt.Quote(clause.Table{Name: r.FieldSchema.Table, Alias: alias})).Select("1")
+	sub.Statement.Table = alias
+	// query clauses of related model like soft delete are applied when building subquery
+	if qb.unscoped {
+		sub = sub.Unscoped()
+	}
+
+	if jt := r.JoinTable; jt != nil {
+		// many2many match by join table
+		var on []clause.Expression
+		for _, ref := range r.References {
+			fk := clause.Column{Table: jt.Table, Name: ref.ForeignKey.DBName}
+			switch {
+			case ref.PrimaryValue != "":
+				on = append(on, clause.Eq{Column: fk, Value: ref.PrimaryValue})
+			case ref.OwnPrimaryKey:
+				sub = sub.Where(clause.Eq{Column: fk, Value: clause.Column{Table: qb.table, Name: ref.PrimaryKey.DBName}})
+			default:
+				on = append(on, clause.Eq{Column: fk, Value: clause.Column{Table: alias, Name: ref.PrimaryKey.DBName}})
+			}
+		}
+		sub = sub.Joins("JOIN ? ON ?", clause.Table{Name: jt.Table}, clause.And(on...))
+	} else {
+		sub = sub.Where(clause.And(relationOn(r, qb.table, alias)...))
+	}
+
+	if len(names) > 1 || cond != nil {
+		sqb := newQueryBuilder(sub, r.FieldSchema)
+		sqb.unscoped = qb.unscoped
+		var expr clause.Expression
+		if len(names) > 1 {
+			expr, err = sqb.visitHas(names[1:], cond)
+		} else {
+			expr, err = sqb.condition(cond)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sub = sqb.db.Where(expr)
+	}
+	return clause.Expr{SQL: "EXISTS (?)", Vars: []interface{}{sub}}, nil
+}
+
+func relationNames(st *schema.Schema) []string {
+	out := make([]string, 0, len(st.Relationships.Relations))
+	for k := range st.Relationships.Relations {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// getRelation find relation by name, fallback to case-insensitive match
+func getRelation(st *schema.Schema, name string) *schema.Relationship {
+	if r, ok := st.Relationships.Relations[name]; ok {
+		return r
+	}
+	for k, r := range st.Relationships.Relations {
+		if strings.EqualFold(k, name) {
+			return r
+		}
 	}
-	return
+	return nil
 }
 
 func getDBName(st *schema.Schema, name string) (string, bool) {
@@ -248,12 +695,14 @@ func normalizeSQL(s string) string {
 }
 
 var normalize = map[string]string{
-	"gt":  ">",
-	"gte": ">=",
-	"eq":  "=",
-	"neq": "<>",
-	"lt":  "<",
-	"lte": "<=",
-	"and": "and",
-	"or":  "or",
+	"gt":       ">",
+	"gte":      ">=",
+	"eq":       "=",
+	"neq":      "<>",
+	"lt":       "<",
+	"lte":      "<=",
+	"in":       "IN",
+	"not in":   "NOT IN",
+	"like":     "LIKE",
+	"not like": "NOT LIKE",
 }
@@ -2,13 +2,17 @@ package gormq
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/go-miniquery/miniquery"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 func getPreparedDB(t *testing.T) *gorm.DB {
@@ -46,8 +50,8 @@ func TestQueryBuild(t *testing.T) {
 		// pg do not support reference alias in where
 		// {Q: `Profile.age > 1`, Where: "`Profile__age` > ?", Vars: []interface{}{1}},
 		{Q: `Profile.AGE > 1`, Where: "`Profile`.`age` > ?", Vars: []interface{}{1}},
-		{Q: `Username = 'wener' and fullName is not null`, Where: "`username` = ? and `full_name` is not null", Vars: []interface{}{"wener"}},
-		{Q: `2021 = date(CreatedAt)`, Where: "? = date(`created_at`)", Vars: []interface{}{2021}},
+		{Q: `Username = 'wener' and fullName is not null`, Where: "`users`.`username` = ? AND `users`.`full_name` IS NOT NULL", Vars: []interface{}{"wener"}},
+		{Q: `2021 = date(CreatedAt)`, Where: "? = date(`users`.`created_at`)", Vars: []interface{}{2021}},
 		{Q: `2021 > 0`},
 		{Q: `1=2`},
 		{Q: `2021 between 1 and 2`},
@@ -81,6 +85,336 @@ func TestQueryBuild(t *testing.T) {
 	}
 }
 
+func TestClause(t *testing.T) {
+	db := getPreparedDB(t)
+	var users []User
+	query := db.Model(User{}).Scopes(ApplyMiniQuery(`username = 'wener' and (Profile.age in [1, 2] or full_name is null)`)).Session(&gorm.Session{DryRun: true}).Find(&users)
+	assert.NoError(t, query.Error)
+	where, ok := query.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	assert.True(t, ok)
+	assert.Equal(t, []clause.Expression{clause.AndConditions{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "username"}, Value: "wener"},
+		clause.OrConditions{Exprs: []clause.Expression{
+			clause.IN{Column: clause.Column{Table: "Profile", Name: "age"}, Values: []interface{}{1, 2}},
+			clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "full_name"}, Value: nil},
+		}},
+	}}}, where.Exprs)
+	assert.Contains(t, query.Statement.SQL.String(), "WHERE `users`.`username` = ? AND (`Profile`.`age` IN (?,?) OR `users`.`full_name` IS NULL)")
+
+	for _, test := range []struct {
+		Q string
+		E string
+	}{
+		{Q: `not (username = 'a' and full_name != 'b')`, E: "WHERE NOT (`users`.`username` = ? AND `users`.`full_name` <> ?)"},
+		{Q: `username not in ['a', 'b'] and full_name not like 'b%'`, E: "WHERE `users`.`username` NOT IN (?,?) AND `users`.`full_name` NOT LIKE ?"},
+		{Q: `Profile.age not between 1 and 2 or username is true`, E: "WHERE ((`Profile`.`age` NOT BETWEEN ? AND ?) OR `users`.`username` IS TRUE)"},
+		{Q: `date(created_at) >= '2021-01-01'`, E: "WHERE date(`users`.`created_at`) >= ?"},
+		{Q: `username like 'w\_%' or username not like '%\%'`, E: "WHERE (`users`.`username` LIKE ? ESCAPE ? OR `users`.`username` NOT LIKE ? ESCAPE ?)"},
+	} {
+		query = db.Model(User{}).Scopes(ApplyMiniQuery(test.Q)).Session(&gorm.Session{DryRun: true}).Find(&users)
+		assert.NoError(t, query.Error, test.Q)
+		assert.Contains(t, query.Statement.SQL.String(), test.E, test.Q)
+	}
+}
+
+type Account struct {
+	ID       uint `gorm:"primarykey"`
+	Name     string
+	Address  AccountAddress         `gorm:"embedded;embeddedPrefix:address_"`
+	Settings map[string]interface{} `gorm:"serializer:json"`
+}
+
+type AccountAddress struct {
+	City string
+	Zip  string
+}
+
+func TestEmbeddedAndJSON(t *testing.T) {
+	db := getPreparedDB(t)
+	assert.NoError(t, db.AutoMigrate(Account{}))
+	db.Where("1 = 1").Delete(&Account{})
+	db.Create(&Account{Name: "a", Address: AccountAddress{City: "Shanghai"}, Settings: map[string]interface{}{"theme": "dark", "font": map[string]interface{}{"size": 14}}})
+	db.Create(&Account{Name: "b", Address: AccountAddress{City: "Beijing"}, Settings: map[string]interface{}{"theme": "light"}})
+
+	for _, test := range []struct {
+		Q     string
+		Order string
+		E     string
+		Vars  []interface{}
+		Names []string
+		Err   miniquery.ErrorCode
+	}{
+		{Q: `Address.City = 'Shanghai'`, E: "WHERE `accounts`.`address_city` = ?", Names: []string{"a"}},
+		{Q: `address.zip is null or address_city like 'B%'`, Order: `Address.City`, E: "ORDER BY `accounts`.`address_city` ASC", Names: []string{"b"}},
+		{
+			Q:     `Settings.theme = 'dark' and settings.font.size > 12`,
+			E:     "WHERE JSON_EXTRACT(`accounts`.`settings`, ?) = ? AND JSON_EXTRACT(`accounts`.`settings`, ?) > ?",
+			Vars:  []interface{}{`$."theme"`, "dark", `$."font"."size"`, 12},
+			Names: []string{"a"},
+		},
+		{Q: `Settings.theme in ['dark', 'light']`, Names: []string{"a", "b"}},
+		{Q: `Address.Street = 'x'`, Err: miniquery.ErrUnknownField},
+		{Q: `Name.first = 'x'`, Err: miniquery.ErrUnknownRelation},
+		{Q: `Settings.theme = 'dark'`, Order: `Settings.theme`, Err: miniquery.ErrUnsortableField},
+	} {
+		t.Run(test.Q, func(t *testing.T) {
+			var accounts []Account
+			q := MiniQuery{Query: []string{test.Q}, Order: test.Order, Check: true}
+			dry := db.Model(Account{}).Scopes(q.Scope).Session(&gorm.Session{DryRun: true}).Find(&accounts)
+			if test.Err != "" {
+				assert.ErrorIs(t, dry.Error, test.Err)
+				return
+			}
+			assert.NoError(t, dry.Error)
+			assert.Contains(t, dry.Statement.SQL.String(), test.E)
+			if test.Vars != nil {
+				assert.Equal(t, test.Vars, dry.Statement.Vars)
+			}
+
+			assert.NoError(t, db.Model(Account{}).Scopes(q.Scope).Order("name").Find(&accounts).Error)
+			var names []string
+			for _, v := range accounts {
+				names = append(names, v.Name)
+			}
+			assert.Equal(t, test.Names, names)
+		})
+	}
+
+	s, err := GetOrParseSchema(db.Model(Account{}))
+	assert.NoError(t, err)
+	f, err := SchemaOf(s).Lookup([]string{"address", "city"})
+	assert.NoError(t, err)
+	assert.Equal(t, "address_city", f.Column)
+}
+
+func TestLikeEscape(t *testing.T) {
+	db := getPreparedDB(t)
+	var users []User
+	assert.NoError(t, db.Model(User{}).Scopes(ApplyMiniQuery(`username like '`+miniquery.EscapeLike("w_")+`%'`)).Find(&users).Error)
+	assert.Empty(t, users)
+	assert.NoError(t, db.Model(User{}).Scopes(ApplyMiniQuery(`username like 'w_%'`)).Find(&users).Error)
+	assert.NotEmpty(t, users)
+}
+
+func TestCheck(t *testing.T) {
+	db := getPreparedDB(t)
+	for _, test := range []struct {
+		Q    string
+		Vars []interface{}
+		Err  bool
+	}{
+		{Q: `Profile.age > '1'`, Vars: []interface{}{1}},
+		{Q: `username = 1`, Vars: []interface{}{"1"}},
+		{Q: `CreatedAt > '2021-05-12'`, Vars: []interface{}{time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)}},
+		{Q: `created_at like 5`, Err: true},
+		{Q: `Profile.age > 'abc'`, Err: true},
+	} {
+		var users []User
+		query := db.Model(User{}).Scopes(MiniQuery{Query: []string{test.Q}, Check: true}.Scope).Session(&gorm.Session{DryRun: true}).Find(&users)
+		if test.Err {
+			assert.Error(t, query.Error, test.Q)
+			continue
+		}
+		assert.NoError(t, query.Error, test.Q)
+		assert.Equal(t, test.Vars, query.Statement.Vars, test.Q)
+	}
+
+	s, err := GetOrParseSchema(db.Model(User{}))
+	assert.NoError(t, err)
+	ms := SchemaOf(s)
+	f, err := ms.Lookup([]string{"Profile", "Age"})
+	assert.NoError(t, err)
+	assert.Equal(t, miniquery.IntFieldType, f.Type)
+	f, err = ms.Lookup([]string{"created_at"})
+	assert.NoError(t, err)
+	assert.Equal(t, miniquery.TimeFieldType, f.Type)
+
+	d := Describe(s, &miniquery.Policy{Fields: map[string][]miniquery.OpType{"username": {miniquery.OpEQ}, "Profile.*": nil}})
+	assert.Equal(t, Functions, d.Functions)
+	var names []string
+	for _, v := range d.Fields {
+		names = append(names, v.Name)
+	}
+	assert.Contains(t, names, "Profile.Age")
+	assert.Equal(t, "Username", d.Fields[0].Name)
+	assert.Equal(t, []miniquery.OpType{miniquery.OpEQ}, d.Fields[0].Operators)
+	assert.NotContains(t, names, "FullName")
+}
+
+func TestPolicy(t *testing.T) {
+	db := getPreparedDB(t)
+	policy := &miniquery.Policy{Fields: map[string][]miniquery.OpType{
+		"username":  {miniquery.OpEQ},
+		"Profile.*": nil,
+	}}
+	for _, test := range []struct {
+		Q   string
+		Err string
+	}{
+		{Q: `username = 'wener' and Profile.age > 1`},
+		{Q: `username like 'w%'`, Err: `operator "like" not allowed for field "username"`},
+		{Q: `full_name = 'Wener'`, Err: `field not found: "full_name"`},
+		{Q: `missing = 'Wener'`, Err: `field not found: "missing"`},
+	} {
+		var users []User
+		err := db.Model(User{}).Scopes(MiniQuery{Query: []string{test.Q}, Policy: policy}.Scope).Find(&users).Error
+		if test.Err == "" {
+			assert.NoError(t, err, test.Q)
+		} else {
+			assert.EqualError(t, err, test.Err, test.Q)
+		}
+	}
+
+	// parameters are bound after the policy is enforced
+	var users []User
+	err := db.Model(User{}).Scopes(MiniQuery{Query: []string{`username = :me`}, Policy: policy, Params: map[string]interface{}{"me": "wener"}}.Scope).Find(&users).Error
+	assert.NoError(t, err)
+	assert.NotEmpty(t, users)
+
+	// hidden fields are not suggested
+	deny := &miniquery.Policy{Deny: []string{"full_name"}}
+	for _, check := range []bool{false, true} {
+		var users []User
+		err := db.Model(User{}).Scopes(MiniQuery{Query: []string{`full_nam = 'x'`}, Policy: deny, Check: check}.Scope).Find(&users).Error
+		assert.EqualError(t, err, `field not found: "full_nam"`)
+		err = db.Model(User{}).Scopes(MiniQuery{Query: []string{`user_nam = 'x'`}, Policy: deny, Check: check}.Scope).Find(&users).Error
+		assert.ErrorContains(t, err, `did you mean`)
+	}
+}
+
+func TestPolicyColumnName(t *testing.T) {
+	db := getPreparedDB(t)
+	assert.NoError(t, db.AutoMigrate(Account{}))
+	for _, deny := range []string{"Address.City", "address_city"} {
+		policy := &miniquery.Policy{Deny: []string{deny}}
+		for _, q := range []MiniQuery{
+			{Query: []string{`Address.City = 'x'`}},
+			{Query: []string{`address_city = 'x'`}},
+			{Query: []string{`name = 'x'`}, Order: `address_city`},
+		} {
+			q.Policy = policy
+			var accounts []Account
+			err := db.Model(Account{}).Scopes(q.Scope).Find(&accounts).Error
+			assert.ErrorIs(t, err, miniquery.ErrUnknownField, "%s %v %s", deny, q.Query, q.Order)
+		}
+		query, _ := MiniQuery{Policy: policy}.List(db.Model(Account{}), &miniquery.ListRequest{Fields: []string{"address_city"}})
+		var accounts []Account
+		assert.ErrorIs(t, query.Find(&accounts).Error, miniquery.ErrUnknownField, deny)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	db := getPreparedDB(t)
+	resolver := &miniquery.FieldMapping{Aliases: map[string]string{
+		"name": "username",
+		"age":  "Profile.age",
+	}}
+	var users []User
+	query := db.Model(User{}).Scopes(MiniQuery{Query: []string{`name = 'xxx' and age > 1`}, Resolver: resolver}.Scope).Session(&gorm.Session{DryRun: true}).Find(&users)
+	assert.NoError(t, query.Error)
+	assert.Contains(t, query.Statement.SQL.String(), "WHERE `users`.`username` = ? AND `Profile`.`age` > ?")
+}
+
+func TestVirtuals(t *testing.T) {
+	db := getPreparedDB(t)
+	virtuals := (&miniquery.Virtuals{}).
+		MustDefine("adult", `Profile.age >= 18`).
+		MustDefine("mine", `username = :me`).
+		MustDefine("recent", `created_at > now()`)
+	var users []User
+	query := db.Model(User{}).Scopes(MiniQuery{
+		Query:    []string{`adult and mine`},
+		Virtuals: virtuals,
+		Params:   map[string]interface{}{"me": "xxx"},
+	}.Scope).Find(&users)
+	assert.NoError(t, query.Error)
+	assert.NotEmpty(t, users)
+	for _, v := range users {
+		assert.Equal(t, "xxx", v.Username)
+	}
+
+	query = db.Model(User{}).Scopes(MiniQuery{Query: []string{`not recent`}, Virtuals: virtuals}.Scope).Session(&gorm.Session{DryRun: true}).Find(&users)
+	assert.NoError(t, query.Error)
+	assert.Contains(t, query.Statement.SQL.String(), "WHERE `users`.`created_at` <= CURRENT_TIMESTAMP")
+
+	err := db.Model(User{}).Scopes(MiniQuery{Query: []string{`mine`}, Virtuals: virtuals}.Scope).Find(&users).Error
+	assert.EqualError(t, err, `unbound parameter: "me"`)
+
+	// definitions use backend names, only the query is resolved by strict mapping
+	resolver := &miniquery.FieldMapping{Aliases: map[string]string{"name": "username"}, Strict: true}
+	query = db.Model(User{}).Scopes(MiniQuery{Query: []string{`recent and name = 'xxx'`}, Virtuals: virtuals, Resolver: resolver}.Scope).Session(&gorm.Session{DryRun: true}).Find(&users)
+	assert.NoError(t, query.Error)
+	assert.Contains(t, query.Statement.SQL.String(), "WHERE `users`.`created_at` > CURRENT_TIMESTAMP AND `users`.`username` = ?")
+	err = db.Model(User{}).Scopes(MiniQuery{Query: []string{`recent and created_at > 1`}, Virtuals: virtuals, Resolver: resolver}.Scope).Find(&users).Error
+	assert.ErrorIs(t, err, miniquery.ErrUnknownField)
+}
+
+func TestErrors(t *testing.T) {
+	db := getPreparedDB(t)
+	for _, test := range []struct {
+		Q    string
+		Code miniquery.ErrorCode
+	}{
+		{Q: `a =`, Code: miniquery.ErrSyntax},
+		{Q: `missing = 1`, Code: miniquery.ErrUnknownField},
+		{Q: `Missing.age = 1`, Code: miniquery.ErrUnknownRelation},
+		{Q: `Profile.missing = 1`, Code: miniquery.ErrUnknownField},
+		{Q: `unknown(1) = 1`, Code: miniquery.ErrUnsupportedFunction},
+	} {
+		var users []User
+		err := db.Model(User{}).Scopes(MiniQuery{Query: []string{test.Q}}.Scope).Session(&gorm.Session{DryRun: true}).Find(&users).Error
+		assert.ErrorIs(t, err, test.Code, test.Q)
+	}
+
+	var users []User
+	err := db.Model(User{}).Scopes(ApplyMiniQuery(`usrname = 'wener'`)).Find(&users).Error
+	assert.EqualError(t, err, `field not found: "usrname", did you mean "username"?`)
+	err = db.Model(User{}).Scopes(ApplyMiniQuery(`Profle.age > 1`)).Find(&users).Error
+	assert.EqualError(t, err, `relation not found: "Profle", did you mean "Profile"?`)
+	err = db.Model(User{}).Scopes(ApplyMiniQuery(`Profile.aeg > 1`)).Find(&users).Error
+	assert.EqualError(t, err, `field not found: "Profile.aeg", did you mean "age"?`)
+	err = db.Model(User{}).Scopes(ApplyMiniQuery(`dat(created_at) = 1`)).Find(&users).Error
+	assert.EqualError(t, err, `unsupported function: "dat", did you mean "date"?`)
+}
+
+func TestEvalParity(t *testing.T) {
+	db := getPreparedDB(t)
+	var all []User
+	assert.NoError(t, db.Preload("Profile").Find(&all).Error)
+	for _, q := range []string{
+		`Profile.age > 1`,
+		`Profile.age is null`,
+		`not Profile.age = 18`,
+		`Profile.age between 10 and 20 or username = 'wener'`,
+		`username like 'W%'`,
+		`username not in ('xxx', null)`,
+		`full_name is not null and not (Profile.age < 18)`,
+		`not (username = 'wener' and Profile.age is null)`,
+		`username not like 'w%' or Profile.age in [18, 20]`,
+		`Profile.age not in []`,
+		`Profile.age in []`,
+		`not (Profile.age not in [])`,
+	} {
+		var users []User
+		assert.NoError(t, db.Model(User{}).Scopes(MiniQuery{Query: []string{q}}.Scope).Find(&users).Error, q)
+		var expected []uint
+		for _, v := range users {
+			expected = append(expected, v.ID)
+		}
+		node, err := miniquery.Parse(q)
+		assert.NoError(t, err)
+		var actual []uint
+		for _, v := range all {
+			ok, err := miniquery.Eval(node, v)
+			assert.NoError(t, err, q)
+			if ok {
+				actual = append(actual, v.ID)
+			}
+		}
+		assert.ElementsMatch(t, expected, actual, q)
+	}
+}
+
 func TestGormQuery(t *testing.T) {
 	db := getPreparedDB(t)
 	user := &User{}
@@ -104,3 +438,162 @@ type UserProfile struct {
 	UpdatedAt time.Time
 	Age       int
 }
+
+func TestOrder(t *testing.T) {
+	db := getPreparedDB(t)
+	for _, test := range []struct {
+		Q     string
+		Order string
+		E     string
+		Err   miniquery.ErrorCode
+	}{
+		{Order: `createdAt desc, id`, E: "ORDER BY `users`.`created_at` DESC, `users`.`id` ASC"},
+		{Order: `Profile.age asc nulls last`, E: "ORDER BY CASE WHEN `Profile`.`age` IS NULL THEN 1 ELSE 0 END, `Profile`.`age` ASC"},
+		{Q: `Profile.age > 1`, Order: `Profile.age desc nulls first, username`, E: "WHERE `Profile`.`age` > ? ORDER BY CASE WHEN `Profile`.`age` IS NULL THEN 0 ELSE 1 END, `Profile`.`age` DESC, `users`.`username` ASC"},
+		{Order: `Profile.age desc nulls last, id asc`, E: "ORDER BY CASE WHEN `Profile`.`age` IS NULL THEN 1 ELSE 0 END, `Profile`.`age` DESC, `users`.`id` ASC"},
+		{Order: `date(users.created_at) desc`, Err: miniquery.ErrUnknownRelation},
+		{Order: `name`, Err: miniquery.ErrUnknownField},
+		{Order: `username = 1`, Err: miniquery.ErrInvalidOrder},
+	} {
+		var users []User
+		query := db.Model(User{}).Scopes(MiniQuery{Query: []string{test.Q}, Order: test.Order}.Scope).Session(&gorm.Session{DryRun: true}).Find(&users)
+		if test.Err != "" {
+			assert.ErrorIs(t, query.Error, test.Err, test.Order)
+			continue
+		}
+		assert.NoError(t, query.Error, test.Order)
+		s := query.Statement.SQL.String()
+		assert.Contains(t, s, test.E, test.Order)
+		assert.NoError(t, db.Exec(s, query.Statement.Vars...).Error)
+	}
+
+	// joined once with filter scope
+	var users []User
+	err := db.Model(User{}).Scopes(ApplyMiniQuery(`Profile.age >= 0`), ApplyOrder(`Profile.age desc`)).Find(&users).Error
+	assert.NoError(t, err)
+	for i := 1; i < len(users); i++ {
+		assert.GreaterOrEqual(t, users[i-1].Profile.Age, users[i].Profile.Age)
+	}
+
+	policy, _ := miniquery.ParsePolicy(`username; -Profile`)
+	err = db.Model(User{}).Scopes(MiniQuery{Order: `Profile.age`, Policy: policy}.Scope).Find(&users).Error
+	assert.EqualError(t, err, `field not found: "Profile.age"`)
+	resolver := &miniquery.FieldMapping{Aliases: map[string]string{"age": "Profile.age"}}
+	err = db.Model(User{}).Scopes(MiniQuery{Order: `age desc`, Resolver: resolver}.Scope).Find(&users).Error
+	assert.NoError(t, err)
+}
+
+func TestCursor(t *testing.T) {
+	db := getPreparedDB(t)
+	order := `username desc, id`
+	orders, err := miniquery.ParseOrder(order)
+	assert.NoError(t, err)
+
+	cursor, err := miniquery.NewCursor(orders, User{ID: 3, Username: "wener"})
+	assert.NoError(t, err)
+	var users []User
+	query := db.Model(User{}).Scopes(MiniQuery{Query: []string{`fullName is not null`}, Order: order, Cursor: cursor}.Scope).Session(&gorm.Session{DryRun: true}).Find(&users)
+	assert.NoError(t, query.Error)
+	assert.Contains(t, query.Statement.SQL.String(), "WHERE `users`.`full_name` IS NOT NULL AND (`users`.`username` < ? OR (`users`.`username` = ? AND `users`.`id` > ?)) ORDER BY `users`.`username` DESC, `users`.`id` ASC")
+	assert.Equal(t, []interface{}{"wener", "wener", 3}, query.Statement.Vars)
+
+	// duplicate sort values are paged by the unique tie-breaker, order without unique field is rejected
+	db.Create(&[]User{{Username: "tie", FullName: "tie"}, {Username: "tie", FullName: "tie"}, {Username: "tie", FullName: "tie"}})
+	for _, order := range []string{`username desc`, `username, createdAt`, `Profile.id`} {
+		orders, err := miniquery.ParseOrder(order)
+		assert.NoError(t, err)
+		cursor, err := miniquery.NewCursor(orders, User{Username: "tie", Profile: &UserProfile{ID: 1}})
+		assert.NoError(t, err)
+		err = db.Model(User{}).Scopes(MiniQuery{Order: order, Cursor: cursor}.Scope).Find(&users).Error
+		assert.ErrorIs(t, err, miniquery.ErrNonUniqueOrder, order)
+	}
+
+	// relation is joined, root columns have the same name as columns of relation
+	for _, order := range []string{order, `Profile.age desc nulls last, id asc`} {
+		orders, err := miniquery.ParseOrder(order)
+		assert.NoError(t, err)
+		var all []User
+		assert.NoError(t, db.Model(User{}).Scopes(ApplyOrder(order)).Find(&all).Error, order)
+		var got []User
+		cursor = ""
+		for range all {
+			var page []User
+			err = db.Model(User{}).Scopes(MiniQuery{Order: order, Cursor: cursor}.Scope).Limit(2).Find(&page).Error
+			assert.NoError(t, err, order)
+			if len(page) == 0 {
+				break
+			}
+			got = append(got, page...)
+			cursor, err = miniquery.NewCursor(orders, page[len(page)-1])
+			assert.NoError(t, err, order)
+		}
+		assert.NotEmpty(t, all, order)
+		usernames := map[string]bool{}
+		for _, v := range all {
+			usernames[v.Username] = true
+		}
+		assert.Less(t, len(usernames), len(all), "duplicate sort values")
+		assert.Equal(t, len(all), len(got), order)
+		for i := range all {
+			assert.Equal(t, all[i].ID, got[i].ID, order)
+		}
+	}
+
+	err = db.Model(User{}).Scopes(MiniQuery{Order: `id`, Cursor: cursor}.Scope).Find(&users).Error
+	assert.ErrorIs(t, err, miniquery.ErrInvalidCursor)
+}
+
+func TestList(t *testing.T) {
+	db := getPreparedDB(t)
+	values, _ := url.ParseQuery(`q=username+%3D+'wener'&order=id+desc&limit=1&offset=1&fields=id,username,createdAt`)
+	req, err := miniquery.ParseListRequest(values, &miniquery.ListOptions{Schema: SchemaOf(mustSchema(t, db, User{}))})
+	assert.NoError(t, err)
+
+	query, count := ApplyList(db.Model(User{}), req)
+	var total int64
+	assert.NoError(t, count.Count(&total).Error)
+	var users []User
+	assert.NoError(t, query.Find(&users).Error)
+	assert.GreaterOrEqual(t, total, int64(1))
+	assert.LessOrEqual(t, len(users), 1)
+	for _, u := range users {
+		assert.Equal(t, "wener", u.Username)
+		assert.Empty(t, u.FullName)
+	}
+
+	dry := query.Session(&gorm.Session{DryRun: true}).Find(&users)
+	assert.Equal(t, "SELECT `users`.`id`,`users`.`username`,`users`.`created_at` FROM `users` WHERE `users`.`username` = ? ORDER BY `users`.`id` DESC LIMIT 1 OFFSET 1", dry.Statement.SQL.String())
+	dry = count.Session(&gorm.Session{DryRun: true}).Count(&total)
+	assert.Equal(t, "SELECT count(*) FROM `users` WHERE `users`.`username` = ?", dry.Statement.SQL.String())
+
+	// relation is joined by filter, root columns have the same name as columns of relation
+	for _, v := range []string{`q=Profile.age+%3E+1&order=id+desc&limit=5`, `q=Profile.age+%3E+1&order=id+desc&fields=id,username&limit=5`} {
+		values, _ = url.ParseQuery(v)
+		req, err = miniquery.ParseListRequest(values, nil)
+		assert.NoError(t, err, v)
+		query, count = ApplyList(db.Model(User{}), req)
+		assert.NoError(t, count.Count(&total).Error, v)
+		users = nil
+		assert.NoError(t, query.Find(&users).Error, v)
+		assert.NotEmpty(t, users, v)
+		assert.Equal(t, min(int(total), 5), len(users), v)
+		for i, u := range users {
+			assert.NotEmpty(t, u.Username, v)
+			if i > 0 {
+				assert.Greater(t, users[i-1].ID, u.ID, v)
+			}
+		}
+	}
+
+	policy, _ := miniquery.ParsePolicy(`-fullName`)
+	query, _ = MiniQuery{Policy: policy}.List(db.Model(User{}), &miniquery.ListRequest{Fields: []string{"fullName"}})
+	assert.ErrorIs(t, query.Find(&users).Error, miniquery.ErrUnknownField)
+	query, _ = ApplyList(db.Model(User{}), &miniquery.ListRequest{Fields: []string{"usrname"}})
+	assert.EqualError(t, query.Find(&users).Error, `field not found: "usrname", did you mean "username"?`)
+}
+
+func mustSchema(t *testing.T, db *gorm.DB, model interface{}) *schema.Schema {
+	stmt := &gorm.Statement{DB: db}
+	assert.NoError(t, stmt.Parse(model))
+	return stmt.Schema
+}
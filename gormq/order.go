@@ -0,0 +1,96 @@
+package gormq
+
+import (
+	"strings"
+
+	"github.com/wenerme/go-miniquery/miniquery"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApplyOrder apply order string like `createdAt desc, Profile.age asc nulls last`,
+// fields are resolved like filter, relations are joined, to-many relations and json fields are rejected
+func ApplyOrder(order string) func(db *gorm.DB) *gorm.DB {
+	return MiniQuery{Order: order}.Scope
+}
+
+// keyset predicate of Cursor, fields are resolved with the filter
+func (q MiniQuery) keyset() (*miniquery.Node, error) {
+	orders, err := miniquery.ParseOrder(q.Order)
+	if err != nil {
+		return nil, err
+	}
+	return miniquery.KeysetCursor(orders, q.Cursor)
+}
+
+func (q MiniQuery) order(db *gorm.DB, order string) *gorm.DB {
+	orders, err := miniquery.ParseOrder(order)
+	if err != nil || len(orders) == 0 {
+		_ = db.AddError(err)
+		return db
+	}
+	for _, o := range orders {
+		if err = q.Policy.Enforce(o.Field); err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		if err = miniquery.Resolve(o.Field, q.Resolver); err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+	}
+	schema, err := GetOrParseSchema(db)
+	if err != nil {
+		_ = db.AddError(err)
+		return db
+	}
+	if err = miniquery.CheckOrder(orders, SchemaOf(schema)); err != nil {
+		_ = db.AddError(q.Policy.Redact(err))
+		return db
+	}
+	if q.Cursor != "" {
+		if err = miniquery.CheckKeyset(orders, SchemaOf(schema)); err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+	}
+
+	qb := newQueryBuilder(db, schema)
+	qb.unscoped = q.Unscoped
+	sql := &strings.Builder{}
+	var vals []interface{}
+	for i, o := range orders {
+		v, err := qb.operand(o.Field)
+		if err != nil {
+			_ = qb.db.AddError(q.Policy.Redact(err))
+			return qb.db
+		}
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		writeOrder(sql, o)
+		if o.Nulls != "" {
+			// expression is written twice
+			vals = append(vals, v)
+		}
+		vals = append(vals, v)
+	}
+	return qb.db.Order(clause.OrderBy{Expression: clause.Expr{SQL: sql.String(), Vars: vals, WithoutParentheses: true}})
+}
+
+// writeOrder write order term of the expression placeholder, NULLS FIRST/LAST is emulated by case for databases without it
+func writeOrder(sql *strings.Builder, o *miniquery.Order) {
+	if o.Nulls != "" {
+		if o.Nulls == miniquery.NullsFirst {
+			sql.WriteString("CASE WHEN ? IS NULL THEN 0 ELSE 1 END, ")
+		} else {
+			sql.WriteString("CASE WHEN ? IS NULL THEN 1 ELSE 0 END, ")
+		}
+	}
+	sql.WriteString("?")
+	if o.Desc {
+		sql.WriteString(" DESC")
+	} else {
+		sql.WriteString(" ASC")
+	}
+}
@@ -0,0 +1,100 @@
+package gormq
+
+import (
+	"database/sql"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/wenerme/go-miniquery/miniquery"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	uuidType    = reflect.TypeOf(uuid.UUID{})
+)
+
+// SchemaOf derive miniquery schema from gorm schema, relations are derived recursively
+func SchemaOf(st *schema.Schema) *miniquery.Schema {
+	return schemaOf(st, map[*schema.Schema]*miniquery.Schema{})
+}
+
+// Describe describe filterable fields of gorm schema under policy, see miniquery.Describe
+func Describe(st *schema.Schema, p *miniquery.Policy) *miniquery.Description {
+	return miniquery.Describe(SchemaOf(st), p, Functions)
+}
+
+func schemaOf(st *schema.Schema, seen map[*schema.Schema]*miniquery.Schema) *miniquery.Schema {
+	if s, ok := seen[st]; ok {
+		return s
+	}
+	s := &miniquery.Schema{Name: st.Name}
+	seen[st] = s
+	for _, f := range st.Fields {
+		if f.DBName == "" {
+			continue
+		}
+		typ, ok := fieldType(f)
+		if !ok {
+			continue
+		}
+		s.Fields = append(s.Fields, &miniquery.SchemaField{
+			// fields of embedded struct are named by path like Address.City
+			Name:     strings.Join(f.EmbeddedBindNames, "."),
+			Column:   f.DBName,
+			Type:     typ,
+			Nullable: isNullable(f),
+			Unique:   f.PrimaryKey || f.Unique,
+		})
+	}
+	for name, r := range st.Relationships.Relations {
+		if r.FieldSchema == nil {
+			continue
+		}
+		if s.Relations == nil {
+			s.Relations = map[string]*miniquery.Schema{}
+		}
+		s.Relations[name] = schemaOf(r.FieldSchema, seen)
+		if r.Type == schema.HasMany || r.Type == schema.Many2Many {
+			s.ToMany = append(s.ToMany, name)
+		}
+	}
+	sort.Strings(s.ToMany)
+	return s
+}
+
+func fieldType(f *schema.Field) (miniquery.FieldType, bool) {
+	if f.IndirectFieldType == uuidType {
+		return miniquery.UUIDFieldType, true
+	}
+	if f.Serializer != nil {
+		return miniquery.JSONFieldType, true
+	}
+	switch f.GORMDataType {
+	case schema.Bool:
+		return miniquery.BoolFieldType, true
+	case schema.Int, schema.Uint:
+		return miniquery.IntFieldType, true
+	case schema.Float:
+		return miniquery.FloatFieldType, true
+	case schema.String:
+		return miniquery.StringFieldType, true
+	case schema.Time:
+		return miniquery.TimeFieldType, true
+	case schema.Bytes:
+		return "", false
+	}
+	if strings.Contains(strings.ToLower(string(f.GORMDataType)), "json") {
+		return miniquery.JSONFieldType, true
+	}
+	return miniquery.StringFieldType, true
+}
+
+func isNullable(f *schema.Field) bool {
+	if f.NotNull || f.PrimaryKey {
+		return false
+	}
+	return f.FieldType.Kind() == reflect.Ptr || reflect.PointerTo(f.FieldType).Implements(scannerType)
+}
@@ -0,0 +1,146 @@
+// Package httpmq net/http helpers of miniquery, parse filter of request into context and
+// report query errors as RFC 7807 problem details.
+package httpmq
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+// Options of Middleware
+type Options struct {
+	// Param filter parameter name, default "q"
+	Param string
+	// MaxLength max bytes of filter, default 4096, negative no limit
+	MaxLength int
+	// MaxDepth max depth of filter expression tree, 0 no limit
+	MaxDepth int
+	// MaxLiterals max number of literal values in filter, 0 no limit
+	MaxLiterals int
+	// Schema type check the filter when not nil
+	Schema *miniquery.Schema
+	// Policy restrict filterable fields and operators, also applied to order and fields of List,
+	// names are canonicalized by Schema
+	Policy *miniquery.Policy
+	// List parse list request of order, pagination and fields when not nil, see miniquery.ParseListRequest,
+	// Schema and Policy are used when not set in List
+	List *miniquery.ListOptions
+	// ProblemType prefix of problem type uri, type is prefix + error code, default about:blank
+	ProblemType string
+	// OnError write error response, default WriteError
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type contextKey int
+
+const (
+	queryContextKey contextKey = iota
+	listContextKey
+)
+
+// Middleware parse filter parameter of request into context, errors are written as problem details
+//
+//	mux.Handle("/users", httpmq.Middleware(&httpmq.Options{Schema: schema})(handler))
+//	node := httpmq.QueryFromContext(r.Context()) // nil if no filter
+func Middleware(opts *Options) func(next http.Handler) http.Handler {
+	if opts == nil {
+		opts = &Options{}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := opts.parse(r)
+			if err != nil {
+				if opts.OnError != nil {
+					opts.OnError(w, r, err)
+				} else {
+					writeError(w, r, err, opts.ProblemType)
+				}
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (o *Options) parse(r *http.Request) (context.Context, error) {
+	ctx := r.Context()
+	values := r.URL.Query()
+	param := o.Param
+	if param == "" {
+		param = miniquery.ListParamQuery
+	}
+	q := values.Get(param)
+
+	maxLength := o.MaxLength
+	if maxLength == 0 {
+		maxLength = 4096
+	}
+	if maxLength > 0 && len(q) > maxLength {
+		return nil, limitError("length", len(q), maxLength)
+	}
+
+	if o.List != nil {
+		if param != miniquery.ListParamQuery {
+			values.Set(miniquery.ListParamQuery, q)
+		}
+		// order and fields are validated by the schema and policy of the filter unless set
+		list := *o.List
+		if list.Schema == nil {
+			list.Schema = o.Schema
+		}
+		if list.Policy == nil {
+			list.Policy = o.Policy
+		}
+		req, err := miniquery.ParseListRequest(values, &list)
+		if err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, listContextKey, req)
+	}
+
+	if q == "" {
+		return ctx, nil
+	}
+	node, err := miniquery.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+	if o.MaxDepth > 0 || o.MaxLiterals > 0 {
+		a := miniquery.Analyze(node)
+		if o.MaxDepth > 0 && a.Depth > o.MaxDepth {
+			return nil, limitError("depth", a.Depth, o.MaxDepth)
+		}
+		if o.MaxLiterals > 0 && a.Literals > o.MaxLiterals {
+			return nil, limitError("literals", a.Literals, o.MaxLiterals)
+		}
+	}
+	policy := o.Policy.WithSchema(o.Schema)
+	if err = policy.Enforce(node); err != nil {
+		return nil, policy.Redact(err)
+	}
+	if o.Schema != nil {
+		if err = miniquery.Check(node, o.Schema); err != nil {
+			return nil, policy.Redact(err)
+		}
+	}
+	return context.WithValue(ctx, queryContextKey, node), nil
+}
+
+func limitError(name string, v int, limit int) error {
+	return miniquery.NewError(miniquery.ErrLimitExceeded).WithField(name).WithValue(strconv.Itoa(v)).WithExpected(strconv.Itoa(limit))
+}
+
+// QueryFromContext parsed filter of Middleware, nil if absent
+func QueryFromContext(ctx context.Context) *miniquery.Node {
+	v, _ := ctx.Value(queryContextKey).(*miniquery.Node)
+	return v
+}
+
+// ListFromContext parsed list request of Middleware, nil if Options.List is not set
+func ListFromContext(ctx context.Context) *miniquery.ListRequest {
+	v, _ := ctx.Value(listContextKey).(*miniquery.ListRequest)
+	return v
+}
@@ -0,0 +1,126 @@
+package httpmq
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+func TestMiddleware(t *testing.T) {
+	schema := &miniquery.Schema{
+		Fields: []*miniquery.SchemaField{
+			{Name: "id", Type: miniquery.IntFieldType},
+			{Name: "username", Type: miniquery.StringFieldType},
+			{Name: "age", Type: miniquery.IntFieldType},
+			{Name: "password_hash", Column: "pwd", Type: miniquery.StringFieldType},
+		},
+	}
+	opts := &Options{
+		MaxLength:   64,
+		MaxDepth:    8,
+		MaxLiterals: 3,
+		Schema:      schema,
+		Policy:      &miniquery.Policy{Deny: []string{"password_hash"}},
+		List:        &miniquery.ListOptions{MaxLimit: 10, DefaultOrder: "id"},
+		ProblemType: "https://example.com/problems/",
+	}
+	handler := Middleware(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node := QueryFromContext(r.Context())
+		list := ListFromContext(r.Context())
+		q := ""
+		if node != nil {
+			q = miniquery.Build(node)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"q": q, "limit": list.Limit, "order": list.Order})
+	}))
+
+	for _, test := range []struct {
+		Q        string
+		Lang     string
+		Status   int
+		Body     map[string]interface{}
+		Code     miniquery.ErrorCode
+		Detail   string
+		Position int
+	}{
+		{Q: ``, Status: 200, Body: map[string]interface{}{"q": "", "limit": 10.0, "order": "id"}},
+		{Q: `q=age > 18&limit=100`, Status: 200, Body: map[string]interface{}{"q": "age > 18", "limit": 10.0, "order": "id"}},
+		{Q: `q=age > 18 and`, Status: 400, Code: miniquery.ErrSyntax, Position: 12},
+		{Q: `q=usrname = 'a'`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "usrname", did you mean "username"?`},
+		{Q: `q=usrname = 'a'`, Lang: "zh-CN,zh;q=0.9", Status: 400, Code: miniquery.ErrUnknownField, Detail: `字段不存在: "usrname"，是否要使用 "username"？`},
+		{Q: `q=id in [1,2,3,4]`, Status: 400, Code: miniquery.ErrLimitExceeded, Detail: `query literals 4 exceeds limit 3`},
+		{Q: `q=username like '` + string(make([]byte, 64)) + `'`, Status: 400, Code: miniquery.ErrLimitExceeded},
+		{Q: `limit=x`, Status: 400, Code: miniquery.ErrInvalidParameter},
+		// denied field is not suggested, order and fields are restricted by policy
+		{Q: `q=password_has = 'x'`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "password_has"`},
+		{Q: `q=password_hash = 'x'`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "password_hash"`},
+		{Q: `order=password_hash`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "password_hash"`},
+		{Q: `order=password_has`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "password_has"`},
+		{Q: `fields=id,password_hash`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "password_hash"`},
+		// denied field by column name
+		{Q: `q=pwd = 'x'`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "pwd"`},
+		{Q: `order=pwd`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "pwd"`},
+		{Q: `fields=pwd`, Status: 400, Code: miniquery.ErrUnknownField, Detail: `field not found: "pwd"`},
+	} {
+		values, err := url.ParseQuery(test.Q)
+		assert.NoError(t, err)
+		r := httptest.NewRequest(http.MethodGet, "/users?"+values.Encode(), nil)
+		if test.Lang != "" {
+			r.Header.Set("Accept-Language", test.Lang)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, test.Status, w.Code, test.Q)
+		if test.Status == 200 {
+			var body map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, test.Body, body, test.Q)
+			continue
+		}
+		assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+		var p Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		assert.Equal(t, test.Code, p.Code, test.Q)
+		assert.Equal(t, "https://example.com/problems/"+string(test.Code), p.Type)
+		assert.Equal(t, "/users", p.Instance)
+		assert.Equal(t, "Bad Request", p.Title)
+		if test.Detail != "" {
+			assert.Equal(t, test.Detail, p.Detail, test.Q)
+		}
+		if test.Position != 0 {
+			assert.Equal(t, test.Position, *p.Position, test.Q)
+		}
+	}
+}
+
+func TestMiddlewareParameter(t *testing.T) {
+	// parameters are kept for the handler to bind after the policy is enforced
+	opts := &Options{Policy: &miniquery.Policy{Deny: []string{"password_hash"}}}
+	handler := Middleware(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(miniquery.Build(QueryFromContext(r.Context()))))
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/users?"+url.Values{"q": {"age > :min"}}.Encode(), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "age > :min", w.Body.String())
+}
+
+func TestWriteError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	w := httptest.NewRecorder()
+	WriteError(w, r, errors.New("db down"))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/users"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	WriteError(w, r, miniquery.ErrMissingModel)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"type":"about:blank","title":"Bad Request","status":400,"instance":"/users","code":"missing_model","detail":"missing model"}`, w.Body.String())
+}
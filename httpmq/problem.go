@@ -0,0 +1,91 @@
+package httpmq
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/wenerme/go-miniquery/miniquery"
+)
+
+// ContentType of problem details
+const ContentType = "application/problem+json"
+
+// Problem RFC 7807 problem details with miniquery error extensions
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Code        miniquery.ErrorCode `json:"code,omitempty"`
+	Field       string              `json:"field,omitempty"`
+	Position    *int                `json:"position,omitempty"` // byte offset in query of syntax error
+	Suggestions []string            `json:"suggestions,omitempty"`
+}
+
+// NewProblem problem of error localized by lang, miniquery errors are bad request, others are internal error
+func NewProblem(err error, lang string) *Problem {
+	var e *miniquery.Error
+	if !errors.As(err, &e) {
+		var code miniquery.ErrorCode
+		if errors.As(err, &code) {
+			e = miniquery.NewError(code)
+		}
+	}
+	if e == nil {
+		return &Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+		}
+	}
+	p := &Problem{
+		Type:        "about:blank",
+		Title:       http.StatusText(http.StatusBadRequest),
+		Status:      http.StatusBadRequest,
+		Detail:      e.Message(lang),
+		Code:        e.Code,
+		Field:       e.Field,
+		Suggestions: e.Suggestions,
+	}
+	if e.Offset >= 0 {
+		p.Position = &e.Offset
+	}
+	return p
+}
+
+// WriteError write error as problem details, language is negotiated by Accept-Language
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(w, r, err, "")
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error, typePrefix string) {
+	p := NewProblem(err, Language(r))
+	p.Instance = r.URL.Path
+	if typePrefix != "" && p.Code != "" {
+		p.Type = typePrefix + string(p.Code)
+	}
+	WriteProblem(w, p)
+}
+
+// WriteProblem write problem details with status
+func WriteProblem(w http.ResponseWriter, p *Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Language first language of Accept-Language, default miniquery.DefaultLanguage
+func Language(r *http.Request) string {
+	v := r.Header.Get("Accept-Language")
+	v, _, _ = strings.Cut(v, ",")
+	v, _, _ = strings.Cut(v, ";")
+	v = strings.TrimSpace(v)
+	if v == "" || v == "*" {
+		return miniquery.DefaultLanguage
+	}
+	return v
+}
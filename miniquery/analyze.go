@@ -0,0 +1,161 @@
+package miniquery
+
+import (
+	"slices"
+	"strings"
+)
+
+// Analysis features used by a query, for field level permission checks and auditing
+type Analysis struct {
+	Identifiers []string   // plain field names, sorted
+	References  [][]string // relation paths like Profile.age, also fields used inside has_edge
+	Relations   []string   // relation paths used by references or has_edge like Orders and Orders.Items, sorted
+	Functions   []FunctionUsage
+	Operators   []OpType // sorted
+	Literals    int      // number of literal values, array elements are counted separately
+	Depth       int      // max depth of the expression tree
+}
+
+// FunctionUsage function name with the arity used
+type FunctionUsage struct {
+	Name  string
+	Arity int
+}
+
+// RelationFunctions functions take relation as first argument and apply the rest to the related node
+var RelationFunctions = map[string]bool{
+	"has_edge": true,
+	"has":      true,
+}
+
+// Fields return all referenced field paths joined by dot
+func (a *Analysis) Fields() []string {
+	out := slices.Clone(a.Identifiers)
+	for _, v := range a.References {
+		out = append(out, strings.Join(v, "."))
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// HasField check whether field path is referenced, case-insensitive
+func (a *Analysis) HasField(name string) bool {
+	for _, v := range a.Fields() {
+		if strings.EqualFold(v, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOperator check whether operator is used
+func (a *Analysis) HasOperator(op OpType) bool {
+	return slices.Contains(a.Operators, op)
+}
+
+// Analyze collect referenced fields, relations, functions and operators of the query
+func Analyze(node *Node) *Analysis {
+	az := &analyzer{
+		a:    &Analysis{},
+		refs: map[string]bool{},
+	}
+	if node != nil {
+		az.visit(node, nil, 1)
+	}
+	a := az.a
+	slices.Sort(a.Identifiers)
+	a.Identifiers = slices.Compact(a.Identifiers)
+	slices.Sort(a.Relations)
+	a.Relations = slices.Compact(a.Relations)
+	slices.Sort(a.Operators)
+	a.Operators = slices.Compact(a.Operators)
+	return a
+}
+
+type analyzer struct {
+	a    *Analysis
+	refs map[string]bool
+	fns  map[FunctionUsage]bool
+}
+
+func (az *analyzer) addReference(names []string) {
+	a := az.a
+	key := strings.Join(names, ".")
+	if az.refs[key] {
+		return
+	}
+	az.refs[key] = true
+	a.References = append(a.References, names)
+	az.addRelation(names[:len(names)-1])
+}
+
+// addRelation add relation path and its prefixes like A and A.B of A.B
+func (az *analyzer) addRelation(names []string) {
+	for i := range names {
+		az.a.Relations = append(az.a.Relations, strings.Join(names[:i+1], "."))
+	}
+}
+
+func (az *analyzer) visit(node *Node, prefix []string, depth int) {
+	a := az.a
+	if node == nil {
+		return
+	}
+	if depth > a.Depth {
+		a.Depth = depth
+	}
+	next := depth + 1
+	switch node.Type {
+	case ValueNodeType:
+		if node.ValueType == ArrayValueType {
+			for _, v := range node.Array {
+				az.visit(v, prefix, next)
+			}
+		} else {
+			a.Literals++
+		}
+	case OperationNodeType:
+		a.Operators = append(a.Operators, node.Operation)
+	case IdentifierNodeType:
+		if len(prefix) == 0 {
+			a.Identifiers = append(a.Identifiers, node.Name)
+		} else {
+			az.addReference(append(slices.Clone(prefix), node.Name))
+		}
+	case ReferenceNodeType:
+		az.addReference(append(slices.Clone(prefix), node.Names...))
+	case ParenthesesExpressionType:
+		az.visit(node.Expression, prefix, next)
+	case NotExpressionType:
+		a.Operators = append(a.Operators, OpNot)
+		az.visit(node.Expression, prefix, next)
+	case FunctionExpressionType:
+		fn := FunctionUsage{Name: node.Name, Arity: len(node.Params)}
+		if az.fns == nil {
+			az.fns = map[FunctionUsage]bool{}
+		}
+		if !az.fns[fn] {
+			az.fns[fn] = true
+			a.Functions = append(a.Functions, fn)
+		}
+		params := node.Params
+		if RelationFunctions[node.Name] && len(params) > 0 && params[0].Type == IdentifierNodeType {
+			rel := append(slices.Clone(prefix), params[0].Name)
+			az.addRelation(rel)
+			for _, v := range params[1:] {
+				az.visit(v, rel, next)
+			}
+			return
+		}
+		for _, v := range params {
+			az.visit(v, prefix, next)
+		}
+	default:
+		az.visit(node.Left, prefix, next)
+		az.visit(node.Op, prefix, next)
+		az.visit(node.Right, prefix, next)
+		for _, v := range node.Params {
+			az.visit(v, prefix, next)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package miniquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	n, err := Parse(`username = 'wener' and (Profile.age > 18 or not salary between 1 and 10) and has_edge(Orders, total in [1,2]) and date(createdAt) is not null`)
+	assert.NoError(t, err)
+	a := Analyze(n)
+	assert.Equal(t, []string{"createdAt", "salary", "username"}, a.Identifiers)
+	assert.Equal(t, [][]string{{"Profile", "age"}, {"Orders", "total"}}, a.References)
+	assert.Equal(t, []string{"Orders", "Profile"}, a.Relations)
+	assert.Equal(t, []FunctionUsage{{Name: "has_edge", Arity: 2}, {Name: "date", Arity: 1}}, a.Functions)
+	assert.Equal(t, []OpType{OpAnd, OpBetween, OpEQ, OpGT, OpIn, OpIsNotNull, OpNot, OpOr}, a.Operators)
+	assert.Equal(t, 6, a.Literals)
+	assert.Equal(t, 8, a.Depth)
+	assert.True(t, a.HasField("Orders.total"))
+	assert.True(t, a.HasField("SALARY"))
+	assert.False(t, a.HasField("total"))
+	assert.True(t, a.HasOperator(OpBetween))
+
+	n, err = Parse(`Orders.Items.price > 1 and has(Customer, Orders.Items.sku = 'x') and Items.qty > 0`)
+	assert.NoError(t, err)
+	a = Analyze(n)
+	assert.Equal(t, []string{"Customer", "Customer.Orders", "Customer.Orders.Items", "Items", "Orders", "Orders.Items"}, a.Relations)
+
+	assert.Equal(t, &Analysis{}, Analyze(nil))
+}
@@ -0,0 +1,257 @@
+package miniquery
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Check validate fields, operators and value types of the query against schema,
+// literals compared with fields are coerced to the declared field type in place.
+//
+//	age > '18'                   -> age > 18
+//	created_at > '2021-05-12'    -> created_at > time.Time
+//	id = 'B1A3...'               -> id = uuid.UUID
+func Check(node *Node, schema *Schema) error {
+	if node == nil {
+		return nil
+	}
+	return checkNode(node, schema)
+}
+
+func checkNode(node *Node, s *Schema) (err error) {
+	switch node.Type {
+	case ParenthesesExpressionType, NotExpressionType:
+		return checkNode(node.Expression, s)
+	case LogicExpressionType:
+		if err = checkNode(node.Left, s); err == nil {
+			err = checkNode(node.Right, s)
+		}
+		return
+	case ValueNodeType:
+		return
+	case IdentifierNodeType, ReferenceNodeType, FunctionExpressionType:
+		_, err = checkOperand(node, s)
+		return
+	case PredicatesExpressionType:
+		f, err := checkOperand(node.Left, s)
+		if err == nil && f != nil {
+			err = checkOperator(node.Left, f, node.Op.Operation)
+		}
+		return err
+	case BetweenExpressionType:
+		f, err := checkOperand(node.Left, s)
+		if err != nil || f == nil {
+			return err
+		}
+		if err = checkOperator(node.Left, f, node.Op.Operation); err != nil {
+			return err
+		}
+		for _, v := range node.Params {
+			if err = coerce(v, f, node.Op.Operation); err != nil {
+				return err
+			}
+		}
+		return nil
+	case CompareExpressionType:
+		return checkCompare(node, s)
+	}
+	return NewError(ErrInvalidNode).WithValue(string(node.Type))
+}
+
+func checkCompare(node *Node, s *Schema) error {
+	lf, err := checkOperand(node.Left, s)
+	if err != nil {
+		return err
+	}
+	rf, err := checkOperand(node.Right, s)
+	if err != nil {
+		return err
+	}
+	op := node.Op.Operation
+	switch {
+	case lf != nil:
+		if err = checkOperator(node.Left, lf, op); err == nil {
+			err = coerce(node.Right, lf, op)
+		}
+	case rf != nil:
+		if err = checkOperator(node.Right, rf, op); err == nil {
+			err = coerce(node.Left, rf, op)
+		}
+	}
+	return err
+}
+
+// checkOperand return the field of identifier or reference, nil for other operands
+func checkOperand(node *Node, s *Schema) (*SchemaField, error) {
+	switch node.Type {
+	case IdentifierNodeType:
+		return s.Lookup([]string{node.Name})
+	case ReferenceNodeType:
+		return s.Lookup(node.Names)
+	case FunctionExpressionType:
+		params := node.Params
+		if RelationFunctions[node.Name] && len(params) > 0 && params[0].Type == IdentifierNodeType {
+			rel := s.Relation(params[0].Name)
+			if rel == nil {
+				return nil, NewError(ErrUnknownRelation).WithField(params[0].Name).WithSuggestions(params[0].Name, s.RelationNames())
+			}
+			for _, v := range params[1:] {
+				if err := checkNode(v, rel); err != nil {
+					return nil, err
+				}
+			}
+			return nil, nil
+		}
+		for _, v := range params {
+			if v.Type == ValueNodeType {
+				continue
+			}
+			if err := checkNode(v, s); err != nil {
+				return nil, err
+			}
+		}
+	case ValueNodeType:
+	default:
+		return nil, checkNode(node, s)
+	}
+	return nil, nil
+}
+
+func checkOperator(node *Node, f *SchemaField, op OpType) error {
+	if !f.AllowOperator(op) {
+		return NewError(ErrOperatorNotAllowed).WithField(Build(node)).WithOperator(op).WithExpected(string(f.Type))
+	}
+	return nil
+}
+
+func coerce(v *Node, f *SchemaField, op OpType) (err error) {
+	if v.Type != ValueNodeType {
+		return
+	}
+	switch v.ValueType {
+	case NullValueType:
+		return
+	case ArrayValueType:
+		for _, e := range v.Array {
+			if err = coerce(e, f, op); err != nil {
+				return
+			}
+		}
+		return
+	}
+	mismatch := func() error {
+		return NewError(ErrTypeMismatch).WithField(f.Name).WithValue(fmt.Sprintf("%s %s", v.ValueType, Build(v))).WithExpected(string(f.Type))
+	}
+	switch f.Type {
+	case StringFieldType:
+		switch v.ValueType {
+		case IntValueType:
+			v.ValueType, v.Str = StringValueType, strconv.Itoa(v.Int)
+		case FloatValueType:
+			v.ValueType, v.Str = StringValueType, strconv.FormatFloat(v.Float, 'f', -1, 64)
+		case StringValueType:
+		default:
+			return mismatch()
+		}
+		if len(f.Enum) > 0 && op != OpLike && op != OpNotLike && !slices.Contains(f.Enum, v.Str) {
+			return NewError(ErrInvalidValue).WithField(f.Name).WithValue(v.Str).WithExpected(strings.Join(f.Enum, ", "))
+		}
+	case IntFieldType:
+		switch v.ValueType {
+		case IntValueType:
+		case FloatValueType:
+			if v.Float != float64(int(v.Float)) {
+				return mismatch()
+			}
+			v.ValueType, v.Int = IntValueType, int(v.Float)
+		case StringValueType:
+			i, e := strconv.Atoi(strings.TrimSpace(v.Str))
+			if e != nil {
+				return mismatch()
+			}
+			v.ValueType, v.Int = IntValueType, i
+		default:
+			return mismatch()
+		}
+	case FloatFieldType:
+		switch v.ValueType {
+		case FloatValueType:
+		case IntValueType:
+			v.ValueType, v.Float = FloatValueType, float64(v.Int)
+		case StringValueType:
+			fv, e := strconv.ParseFloat(strings.TrimSpace(v.Str), 64)
+			if e != nil {
+				return mismatch()
+			}
+			v.ValueType, v.Float = FloatValueType, fv
+		default:
+			return mismatch()
+		}
+	case BoolFieldType:
+		switch v.ValueType {
+		case BooleanValueType:
+		case IntValueType:
+			if v.Int != 0 && v.Int != 1 {
+				return mismatch()
+			}
+			v.ValueType, v.Bool = BooleanValueType, v.Int == 1
+		case StringValueType:
+			b, e := strconv.ParseBool(v.Str)
+			if e != nil {
+				return mismatch()
+			}
+			v.ValueType, v.Bool = BooleanValueType, b
+		default:
+			return mismatch()
+		}
+	case TimeFieldType:
+		switch v.ValueType {
+		case TimeValueType:
+		case StringValueType:
+			t, ok := ParseTime(v.Str)
+			if !ok {
+				return mismatch()
+			}
+			v.ValueType, v.Time = TimeValueType, t
+		default:
+			return mismatch()
+		}
+	case UUIDFieldType:
+		switch v.ValueType {
+		case UUIDValueType:
+		case StringValueType:
+			id, e := uuid.Parse(v.Str)
+			if e != nil {
+				return mismatch()
+			}
+			v.ValueType, v.Str, v.UUID = UUIDValueType, id.String(), id
+		default:
+			return mismatch()
+		}
+	}
+	return nil
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime parse common time formats, time without zone is treated as UTC
+func ParseTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
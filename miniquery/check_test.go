@@ -0,0 +1,98 @@
+package miniquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	profile := &Schema{
+		Name: "Profile",
+		Fields: []*SchemaField{
+			{Name: "age", Type: IntFieldType},
+		},
+	}
+	s := &Schema{
+		Name: "User",
+		Fields: []*SchemaField{
+			{Name: "id", Type: UUIDFieldType},
+			{Name: "username", Type: StringFieldType},
+			{Name: "status", Type: StringFieldType, Enum: []string{"active", "locked"}},
+			{Name: "score", Type: FloatFieldType},
+			{Name: "active", Type: BoolFieldType},
+			{Name: "CreatedAt", Column: "created_at", Type: TimeFieldType, Nullable: true},
+			{Name: "email", Type: StringFieldType, Operators: []OpType{OpEQ, OpIn}},
+			{Name: "Address.City", Column: "address_city", Type: StringFieldType},
+			{Name: "Settings", Type: JSONFieldType},
+		},
+		Relations: map[string]*Schema{
+			"Profile": profile,
+			"Orders":  {Name: "Order", Fields: []*SchemaField{{Name: "total", Type: FloatFieldType}}},
+		},
+	}
+	for _, test := range []struct {
+		Q   string
+		E   string
+		Err bool
+	}{
+		{Q: `Profile.age > '18'`, E: `Profile.age > 18`},
+		{Q: `score > 1 and score in [1,'2']`, E: `score > 1.0 && score in [1.0,2.0]`},
+		{Q: `created_at > '2021-05-12'`, E: `created_at > "2021-05-12T00:00:00Z"`},
+		{Q: `createdAt between '2021-05-12' and '2021-05-13 08:00'`},
+		{Q: `id = 'B1A3C6F2-1D0E-4C2B-9E8F-0A1B2C3D4E5F'`, E: `id == "b1a3c6f2-1d0e-4c2b-9e8f-0a1b2c3d4e5f"`},
+		{Q: `active = 'true' and active is not true`},
+		{Q: `'wener' = username and username like '%w%'`},
+		{Q: `status in ('active')`},
+		{Q: `created_at is null and date(created_at) = '2021-01-01'`},
+		{Q: `has_edge(Orders, total > '1.5')`, E: `has_edge(Orders,total > 1.5)`},
+		{Q: `address.city = 1 and address_city like 'a%'`, E: `address.city == "1" && address_city like "a%"`},
+		{Q: `Settings.theme = 'dark' and Settings.font.size > 12`},
+		{Q: `Profile.age > 'abc'`, Err: true},
+		{Q: `Settings = 'dark'`, Err: true},
+		{Q: `Address.zip = '1'`, Err: true},
+		{Q: `created_at like 5`, Err: true},
+		{Q: `created_at > 'yesterday'`, Err: true},
+		{Q: `id = 'abc'`, Err: true},
+		{Q: `status = 'deleted'`, Err: true},
+		{Q: `email like '%a%'`, Err: true},
+		{Q: `password = '1'`, Err: true},
+		{Q: `Profile.name = '1'`, Err: true},
+		{Q: `has_edge(Friends)`, Err: true},
+		{Q: `has_edge(Orders, name = 1)`, Err: true},
+	} {
+		n, err := Parse(test.Q)
+		if !assert.NoError(t, err, test.Q) {
+			continue
+		}
+		err = Check(n, s)
+		if test.Err {
+			assert.Error(t, err, test.Q)
+			continue
+		}
+		if assert.NoError(t, err, test.Q) && test.E != "" {
+			assert.Equal(t, test.E, Build(n))
+		}
+	}
+
+	n, _ := Parse(`created_at > '2021-05-12' and id = 'b1a3c6f2-1d0e-4c2b-9e8f-0a1b2c3d4e5f'`)
+	assert.NoError(t, Check(n, s))
+	assert.Equal(t, time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC), n.Left.Right.Value())
+	assert.Equal(t, uuid.MustParse("b1a3c6f2-1d0e-4c2b-9e8f-0a1b2c3d4e5f"), n.Right.Right.Value())
+}
+
+func TestUUIDValue(t *testing.T) {
+	id := uuid.MustParse("b1a3c6f2-1d0e-4c2b-9e8f-0a1b2c3d4e5f")
+	n := &Node{Type: ValueNodeType, ValueType: UUIDValueType, UUID: id}
+	assert.Equal(t, id, n.Value())
+	assert.Equal(t, `"b1a3c6f2-1d0e-4c2b-9e8f-0a1b2c3d4e5f"`, Build(n))
+
+	n = &Node{Type: ValueNodeType, ValueType: UUIDValueType, Str: id.String()}
+	assert.Equal(t, id, n.Value())
+
+	n = &Node{Type: ValueNodeType, ValueType: UUIDValueType, Str: "bad"}
+	assert.NotPanics(t, func() { n.Value() })
+	assert.Equal(t, "bad", n.Value())
+}
@@ -0,0 +1,479 @@
+package miniquery
+
+import (
+	"reflect"
+	"time"
+)
+
+// Compile compile the query to predicate of T, field accessors are resolved once for the static type of T,
+// like patterns are compiled and in lists are hashed ahead.
+// Fields of struct are checked at compile time, map and interface values are resolved at call time.
+// Values can not be compared at call time are unknown, use Eval to get the error.
+// The returned predicate is safe for concurrent use.
+func Compile[T any](node *Node) (func(T) bool, error) {
+	if node == nil {
+		return func(T) bool { return true }, nil
+	}
+	cond, err := compileCondition(node, reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return func(v T) bool {
+		return cond(reflect.ValueOf(&v).Elem()) == triTrue
+	}, nil
+}
+
+type (
+	compiledCondition func(v reflect.Value) tri
+	compiledOperand   func(v reflect.Value) interface{}
+	compiledAccessor  func(v reflect.Value) reflect.Value
+)
+
+func compileCondition(node *Node, t reflect.Type) (compiledCondition, error) {
+	if c, ok, err := compileToMany(node, t); ok {
+		return c, err
+	}
+	switch node.Type {
+	case ParenthesesExpressionType:
+		return compileCondition(node.Expression, t)
+	case NotExpressionType:
+		c, err := compileCondition(node.Expression, t)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) tri { return c(v).not() }, nil
+	case LogicExpressionType:
+		l, err := compileCondition(node.Left, t)
+		if err != nil {
+			return nil, err
+		}
+		r, err := compileCondition(node.Right, t)
+		if err != nil {
+			return nil, err
+		}
+		if node.Op.Operation == OpOr {
+			return func(v reflect.Value) tri {
+				o := l(v)
+				if o == triTrue {
+					return o
+				}
+				return o.or(r(v))
+			}, nil
+		}
+		return func(v reflect.Value) tri {
+			o := l(v)
+			if o == triFalse {
+				return o
+			}
+			return o.and(r(v))
+		}, nil
+	case CompareExpressionType:
+		return compileCompare(node, t)
+	case BetweenExpressionType:
+		ops, err := compileOperands(t, node.Left, node.Params[0], node.Params[1])
+		if err != nil {
+			return nil, err
+		}
+		op := node.Op.Operation
+		return func(v reflect.Value) tri {
+			o, _ := between(op, ops[0](v), ops[1](v), ops[2](v))
+			return o
+		}, nil
+	case PredicatesExpressionType:
+		val, err := compileOperand(node.Left, t)
+		if err != nil {
+			return nil, err
+		}
+		op := node.Op.Operation
+		return func(v reflect.Value) tri {
+			o, err := predicate(op, val(v))
+			if err != nil {
+				return triUnknown
+			}
+			return o
+		}, nil
+	case FunctionExpressionType:
+		if RelationFunctions[node.Name] {
+			return compileHasEdge(node, t)
+		}
+	case ParameterNodeType:
+		return nil, NewError(ErrUnboundParameter).WithValue(node.Name)
+	}
+	// bare value or field as condition
+	val, err := compileOperand(node, t)
+	if err != nil {
+		return nil, err
+	}
+	return func(v reflect.Value) tri {
+		o, err := truth(val(v))
+		if err != nil {
+			return triUnknown
+		}
+		return o
+	}, nil
+}
+
+func compileCompare(node *Node, t reflect.Type) (compiledCondition, error) {
+	ops, err := compileOperands(t, node.Left, node.Right)
+	if err != nil {
+		return nil, err
+	}
+	l, r := ops[0], ops[1]
+	op := node.Op.Operation
+	switch {
+	case (op == OpIn || op == OpNotIn) && node.Right.Type == ValueNodeType && node.Right.ValueType == ArrayValueType:
+		set := newValueSet(r(reflect.Value{}).([]interface{}))
+		return func(v reflect.Value) tri {
+			o := set.contains(l(v))
+			if op == OpNotIn {
+				return o.not()
+			}
+			return o
+		}, nil
+	case (op == OpLike || op == OpNotLike) && node.Right.Type == ValueNodeType:
+		pattern, ok := r(reflect.Value{}).(string)
+		if !ok {
+			return nil, NewError(ErrInvalidOperand).WithOperator(op).WithValue(Build(node.Right))
+		}
+		re := CompileLike(pattern)
+		return func(v reflect.Value) tri {
+			val := l(v)
+			if val == nil {
+				return triUnknown
+			}
+			o := triOf(re.MatchString(toString(val)))
+			if op == OpNotLike {
+				return o.not()
+			}
+			return o
+		}, nil
+	}
+	return func(v reflect.Value) tri {
+		o, _ := compareOp(op, l(v), r(v))
+		return o
+	}, nil
+}
+
+func compileHasEdge(node *Node, t reflect.Type) (compiledCondition, error) {
+	params := node.Params
+	if len(params) == 0 || len(params) > 2 || params[0].Type != IdentifierNodeType {
+		return nil, NewError(ErrInvalidEdgeArgs).WithFunction(node.Name).WithValue(Build(node))
+	}
+	rel, rt, err := compileAccessor(t, []string{params[0].Name})
+	if err != nil {
+		return nil, err
+	}
+	rt = derefType(rt)
+	if rt != nil && (rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array) {
+		rt = rt.Elem()
+	}
+	match := func(reflect.Value) tri { return triTrue }
+	if len(params) == 2 {
+		if match, err = compileCondition(params[1], rt); err != nil {
+			return nil, err
+		}
+	}
+	return func(v reflect.Value) tri {
+		v = indirect(rel(v))
+		if !v.IsValid() {
+			return triFalse
+		}
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return match(v)
+		}
+		for i := 0; i < v.Len(); i++ {
+			if match(v.Index(i)) == triTrue {
+				return triTrue
+			}
+		}
+		return triFalse
+	}, nil
+}
+
+// compileToMany match condition on field through slice relation against any element like evalToMany
+func compileToMany(node *Node, t reflect.Type) (compiledCondition, bool, error) {
+	if node = referenceOnLeft(node); node == nil {
+		return nil, false, nil
+	}
+	names := node.Left.Names
+	ft := t
+	for i, name := range names[:len(names)-1] {
+		ft = derefType(ft)
+		if ft == nil || ft.Kind() == reflect.Interface || ft.Kind() == reflect.Map {
+			// resolved at call time
+			return func(v reflect.Value) tri {
+				o, err := evalCondition(node, v)
+				if err != nil {
+					return triUnknown
+				}
+				return o
+			}, true, nil
+		}
+		if ft.Kind() != reflect.Struct {
+			return nil, false, nil
+		}
+		idx, ok := structFieldIndex(ft, name)
+		if !ok {
+			return nil, false, nil
+		}
+		ft = derefType(ft.FieldByIndex(idx).Type)
+		if ft.Kind() != reflect.Array && (ft.Kind() != reflect.Slice || ft.Elem().Kind() == reflect.Uint8) {
+			continue
+		}
+		rel, _, err := compileAccessor(t, names[:i+1])
+		if err != nil {
+			return nil, true, err
+		}
+		sub := *node
+		sub.Left = &Node{Type: ReferenceNodeType, Names: names[i+1:]}
+		match, err := compileCondition(&sub, ft.Elem())
+		if err != nil {
+			return nil, true, err
+		}
+		return func(v reflect.Value) tri {
+			v = indirect(rel(v))
+			if !v.IsValid() {
+				return triFalse
+			}
+			for j := 0; j < v.Len(); j++ {
+				if match(v.Index(j)) == triTrue {
+					return triTrue
+				}
+			}
+			return triFalse
+		}, true, nil
+	}
+	return nil, false, nil
+}
+
+func compileOperands(t reflect.Type, nodes ...*Node) ([]compiledOperand, error) {
+	out := make([]compiledOperand, len(nodes))
+	for i, node := range nodes {
+		v, err := compileOperand(node, t)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func compileOperand(node *Node, t reflect.Type) (compiledOperand, error) {
+	switch node.Type {
+	case ValueNodeType:
+		val, err := evalOperand(node, reflect.Value{})
+		if err != nil {
+			return nil, err
+		}
+		return func(reflect.Value) interface{} { return val }, nil
+	case IdentifierNodeType, ReferenceNodeType:
+		names := node.Names
+		if node.Type == IdentifierNodeType {
+			names = []string{node.Name}
+		}
+		access, ft, err := compileAccessor(t, names)
+		if err != nil {
+			return nil, err
+		}
+		normalize := compileNormalizer(ft)
+		return func(v reflect.Value) interface{} { return normalize(access(v)) }, nil
+	case FunctionExpressionType:
+		return compileFunction(node, t)
+	case ParameterNodeType:
+		return nil, NewError(ErrUnboundParameter).WithValue(node.Name)
+	}
+	c, err := compileCondition(node, t)
+	if err != nil {
+		return nil, err
+	}
+	return func(v reflect.Value) interface{} {
+		o := c(v)
+		if o == triUnknown {
+			return nil
+		}
+		return o == triTrue
+	}, nil
+}
+
+func compileFunction(node *Node, t reflect.Type) (compiledOperand, error) {
+	switch node.Name {
+	case "now":
+		return func(reflect.Value) interface{} { return time.Now() }, nil
+	case "date":
+		if len(node.Params) != 1 {
+			return nil, NewError(ErrInvalidArguments).WithFunction(node.Name).WithExpected("1 argument")
+		}
+		arg, err := compileOperand(node.Params[0], t)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) interface{} {
+			tv, ok := toTime(arg(v))
+			if !ok {
+				return nil
+			}
+			y, m, d := tv.Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, tv.Location())
+		}, nil
+	case "has_edge", "has":
+		c, err := compileHasEdge(node, t)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) interface{} { return c(v) == triTrue }, nil
+	}
+	return nil, NewError(ErrUnsupportedFunction).WithFunction(node.Name).WithSuggestions(node.Name, evalFunctions)
+}
+
+// compileAccessor resolve struct fields ahead, return the field type or nil when resolved at call time
+func compileAccessor(t reflect.Type, names []string) (compiledAccessor, reflect.Type, error) {
+	var steps []compiledAccessor
+	for i, name := range names {
+		t = derefType(t)
+		if t == nil || t.Kind() == reflect.Interface || t.Kind() == reflect.Map {
+			if t != nil && t.Kind() == reflect.Map && t.Key().Kind() != reflect.String {
+				return nil, nil, NewError(ErrUnknownField).WithField(names[:i+1]...)
+			}
+			rest := names[i:]
+			steps = append(steps, func(v reflect.Value) reflect.Value {
+				out, _ := lookupPath(v, rest)
+				return out
+			})
+			t = nil
+			break
+		}
+		var idx []int
+		ok := false
+		if t.Kind() == reflect.Struct {
+			idx, ok = structFieldIndex(t, name)
+		}
+		if !ok {
+			return nil, nil, NewError(ErrUnknownField).WithField(names[:i+1]...).WithSuggestions(name, structFieldNames(t))
+		}
+		steps = append(steps, func(v reflect.Value) reflect.Value {
+			v = indirect(v)
+			if !v.IsValid() {
+				return v
+			}
+			out, err := v.FieldByIndexErr(idx)
+			if err != nil {
+				return reflect.Value{}
+			}
+			return out
+		})
+		t = t.FieldByIndex(idx).Type
+	}
+	if len(steps) == 1 {
+		return steps[0], t, nil
+	}
+	return func(v reflect.Value) reflect.Value {
+		for _, step := range steps {
+			v = step(v)
+		}
+		return v
+	}, t, nil
+}
+
+// compileNormalizer pick the conversion for the static field type, fallback to normalizeValue
+func compileNormalizer(t reflect.Type) func(v reflect.Value) interface{} {
+	if t == nil || t.Implements(valuerType) || reflect.PointerTo(t).Implements(valuerType) {
+		return normalizeValue
+	}
+	base := derefType(t)
+	if base.Implements(valuerType) || reflect.PointerTo(base).Implements(valuerType) {
+		return normalizeValue
+	}
+	var conv func(v reflect.Value) interface{}
+	switch base.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		conv = func(v reflect.Value) interface{} { return v.Int() }
+	case reflect.Float32, reflect.Float64:
+		conv = func(v reflect.Value) interface{} { return v.Float() }
+	case reflect.Bool:
+		conv = func(v reflect.Value) interface{} { return v.Bool() }
+	case reflect.String:
+		conv = func(v reflect.Value) interface{} { return v.String() }
+	default:
+		return normalizeValue
+	}
+	if base == t {
+		return func(v reflect.Value) interface{} {
+			if !v.IsValid() {
+				return nil
+			}
+			return conv(v)
+		}
+	}
+	return func(v reflect.Value) interface{} {
+		if v = indirect(v); !v.IsValid() {
+			return nil
+		}
+		return conv(v)
+	}
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// valueSet hashed in list, fallback to compare when the value kind is not in the set
+type valueSet struct {
+	values  []interface{}
+	set     map[interface{}]struct{}
+	kinds   map[reflect.Kind]bool
+	hasNull bool
+}
+
+func newValueSet(values []interface{}) *valueSet {
+	s := &valueSet{values: values, set: map[interface{}]struct{}{}, kinds: map[reflect.Kind]bool{}}
+	for _, v := range values {
+		if v == nil {
+			s.hasNull = true
+			continue
+		}
+		if k, ok := setKey(v); ok {
+			s.set[k] = struct{}{}
+			s.kinds[reflect.TypeOf(k).Kind()] = true
+		} else {
+			s.kinds[reflect.Invalid] = true
+		}
+	}
+	return s
+}
+
+func (s *valueSet) contains(v interface{}) tri {
+	if v == nil {
+		if len(s.values) == 0 {
+			return triFalse
+		}
+		return triUnknown
+	}
+	k, ok := setKey(v)
+	if ok {
+		if _, found := s.set[k]; found {
+			return triTrue
+		}
+	}
+	// only one kind, the miss is definitive
+	if !ok || len(s.kinds) != 1 || !s.kinds[reflect.TypeOf(k).Kind()] {
+		o, _ := in(v, s.values)
+		return o
+	}
+	if s.hasNull {
+		return triUnknown
+	}
+	return triFalse
+}
+
+// setKey canonical hash key, integers are kept as int64 to not lose precision above 2^53,
+// 1 and 1.0 have different kinds and are matched by compare
+func setKey(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case int64, float64, string, bool:
+		return v, true
+	}
+	return nil, false
+}
@@ -0,0 +1,138 @@
+package miniquery
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	bio := "Go developer"
+	users := []evalUser{
+		{ID: 1, Username: "wener", Active: true, Profile: &evalProfile{Age: 18, Bio: &bio}, Orders: []evalOrder{{Amount: 10, Status: "done"}}},
+		{ID: 2, Username: "xxx", Profile: &evalProfile{Age: 30}},
+		{ID: 3, Username: "yyy"},
+	}
+	for _, q := range []string{
+		`id = 1`,
+		`name like 'W%' or Profile.age > 20`,
+		`Profile.age between 10 and 20`,
+		`not Profile.age = 18`,
+		`id in (1, 3, '2')`,
+		`id not in (1, null)`,
+		`name in ('wener', 'xxx')`,
+		`Profile.bio is null and active is not true`,
+		`score is null`,
+		`has_edge(Orders, status = 'done')`,
+		`has_edge(Profile)`,
+		`Orders.status = 'done'`,
+		`not Orders.amount > 5`,
+		`5 < Orders.amount`,
+		`'done' = Orders.status`,
+		`20 <= Orders.amount`,
+		`date(created_at) < now()`,
+		`name > 1`,
+	} {
+		node, err := Parse(q)
+		assert.NoError(t, err, q)
+		match, err := Compile[evalUser](node)
+		assert.NoError(t, err, q)
+		matchPtr, err := Compile[*evalUser](node)
+		assert.NoError(t, err, q)
+		matchAny, err := Compile[any](node)
+		assert.NoError(t, err, q)
+		for _, u := range users {
+			expected, err := Eval(node, u)
+			if err != nil {
+				expected = false
+			}
+			assert.Equal(t, expected, match(u), "%v %v", q, u.ID)
+			assert.Equal(t, expected, matchPtr(&u), "%v %v", q, u.ID)
+			assert.Equal(t, expected, matchAny(u), "%v %v", q, u.ID)
+		}
+	}
+
+	for _, q := range []string{`missing = 1`, `Profile.missing = 1`, `unknown(id)`, `id = :id`, `Orders.missing = 1`, `password = 'x'`, `has_edge(id.x)`} {
+		node, err := Parse(q)
+		assert.NoError(t, err, q)
+		_, err = Compile[evalUser](node)
+		assert.Error(t, err, q)
+	}
+
+	match, err := Compile[map[string]interface{}](mustParse(t, `repo.stars > 5 and action in ('push')`))
+	assert.NoError(t, err)
+	assert.True(t, match(map[string]interface{}{"action": "push", "repo": map[string]interface{}{"stars": 10}}))
+	assert.False(t, match(map[string]interface{}{"action": "push"}))
+
+	// large integers beyond float64 precision
+	match, err = Compile[map[string]interface{}](mustParse(t, `id in (9007199254740993, 'x')`))
+	assert.NoError(t, err)
+	assert.False(t, match(map[string]interface{}{"id": int64(9007199254740992)}))
+	assert.True(t, match(map[string]interface{}{"id": int64(9007199254740993)}))
+	match, err = Compile[map[string]interface{}](mustParse(t, `id in (9007199254740993)`))
+	assert.NoError(t, err)
+	assert.False(t, match(map[string]interface{}{"id": int64(9007199254740992)}))
+	assert.True(t, match(map[string]interface{}{"id": 9007199254740993.0}))
+	assert.True(t, match(map[string]interface{}{"id": "9007199254740993"}))
+}
+
+func TestCompileConcurrent(t *testing.T) {
+	match, err := Compile[evalUser](mustParse(t, `name like 'w%' and id in (1, 2)`))
+	assert.NoError(t, err)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				assert.True(t, match(evalUser{ID: 1, Username: "wener"}))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func mustParse(tb testing.TB, s string) *Node {
+	node, err := Parse(s)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return node
+}
+
+func benchmarkUsers() []evalUser {
+	users := make([]evalUser, 1000)
+	for i := range users {
+		users[i] = evalUser{ID: i, Username: fmt.Sprintf("user%d", i), Profile: &evalProfile{Age: i % 100}}
+	}
+	return users
+}
+
+const benchmarkQuery = `name like 'user1%' and Profile.age >= 18 and id in (1, 10, 100, 110, 120, 130)`
+
+func BenchmarkEval(b *testing.B) {
+	users := benchmarkUsers()
+	node := mustParse(b, benchmarkQuery)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, u := range users {
+			_, _ = Eval(node, u)
+		}
+	}
+}
+
+func BenchmarkCompile(b *testing.B) {
+	users := benchmarkUsers()
+	match, err := Compile[evalUser](mustParse(b, benchmarkQuery))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, u := range users {
+			match(u)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package miniquery
+
+import (
+	"errors"
+	"strings"
+)
+
+// SuggestionKind kind of completion suggestion
+type SuggestionKind string
+
+const (
+	FieldSuggestion    SuggestionKind = "field"
+	OperatorSuggestion SuggestionKind = "operator"
+	KeywordSuggestion  SuggestionKind = "keyword"
+	FunctionSuggestion SuggestionKind = "function"
+	ValueSuggestion    SuggestionKind = "value"
+)
+
+// Suggestion completion replace input[Start:End] with Text
+type Suggestion struct {
+	Text   string         `json:"text"`
+	Kind   SuggestionKind `json:"kind"`
+	Detail string         `json:"detail,omitempty"` // field type or operator name
+	Start  int            `json:"start"`            // byte offset
+	End    int            `json:"end"`              // byte offset
+}
+
+// operatorSyntax input syntax of operators
+var operatorSyntax = map[OpType]string{
+	OpEQ: "=", OpNEQ: "!=", OpGT: ">", OpGTE: ">=", OpLT: "<", OpLTE: "<=",
+	OpLike: "like", OpNotLike: "not like", OpIn: "in", OpNotIn: "not in",
+	OpBetween: "between", OpNotBetween: "not between",
+	OpIsNull: "is null", OpIsNotNull: "is not null",
+	OpIsTrue: "is true", OpIsNotTrue: "is not true", OpIsFalse: "is false", OpIsNotFalse: "is not false",
+}
+
+// Complete suggest fields, operators of the field type, enum values, logic keywords and functions at the cursor byte offset,
+// suggestions are filtered by whether the parser accepts the input with the suggestion applied.
+// Fields, relations, operators and functions hidden by policy are not suggested, nil policy allow all.
+func Complete(input string, cursor int, schema *Schema, policy *Policy) []Suggestion {
+	cursor = max(0, min(cursor, len(input)))
+	start, end := wordRange(input, cursor)
+	before, prefix := input[:start], strings.ToLower(input[start:cursor])
+
+	var out []Suggestion
+	add := func(kind SuggestionKind, text, detail, probe string) {
+		if !strings.HasPrefix(strings.ToLower(text), prefix) && !(isQuote(prefix) && strings.HasPrefix(strings.ToLower(text[1:]), prefix[1:])) {
+			return
+		}
+		for _, v := range out {
+			if v.Text == text {
+				return
+			}
+		}
+		if !viablePrefix(before + probe) {
+			return
+		}
+		out = append(out, Suggestion{Text: text, Kind: kind, Detail: detail, Start: start, End: end})
+	}
+
+	words := lastWords(before, 2)
+	var field *SchemaField
+	if len(words) > 0 {
+		field = lookupCompleteField(schema, policy, words[len(words)-1])
+	}
+	switch {
+	case field != nil:
+		// operators after field
+		names := strings.Split(words[len(words)-1], ".")
+		for _, op := range field.AllowedOperators() {
+			text := operatorSyntax[op]
+			if text == "" || policy != nil && !policy.AllowField(names, op) {
+				continue
+			}
+			probe := text + " 1"
+			switch op {
+			case OpIn, OpNotIn:
+				probe = text + " [1]"
+			case OpIsNull, OpIsNotNull, OpIsTrue, OpIsNotTrue, OpIsFalse, OpIsNotFalse:
+				probe = text
+			}
+			add(OperatorSuggestion, text, op, probe)
+		}
+		add(KeywordSuggestion, "and", "", "and")
+		add(KeywordSuggestion, "or", "", "or")
+	case len(words) > 0 && isOperatorWord(words[len(words)-1]):
+		// values after operator
+		if f := lookupCompleteField(schema, policy, words[0]); len(words) == 2 && f != nil {
+			for _, v := range f.Enum {
+				add(ValueSuggestion, Quote(v), string(f.Type), Quote(v))
+			}
+			if f.Type == BoolFieldType {
+				add(ValueSuggestion, "true", string(f.Type), "true")
+				add(ValueSuggestion, "false", string(f.Type), "false")
+			}
+		}
+		for _, fn := range evalFunctions {
+			if policy == nil || policy.AllowFunction(fn) {
+				add(FunctionSuggestion, fn+"(", "", fn+"(x)")
+			}
+		}
+	default:
+		add(KeywordSuggestion, "and", "", "and")
+		add(KeywordSuggestion, "or", "", "or")
+		if schema != nil {
+			walkFields(schema, nil, false, func(names []string, f *SchemaField, _ bool) bool {
+				if f == nil {
+					return policy == nil || policy.AllowRelation(names)
+				}
+				if policy == nil || policy.AllowField(names, "") {
+					add(FieldSuggestion, strings.Join(names, "."), string(f.Type), strings.Join(names, "."))
+				}
+				return true
+			})
+		}
+		for _, fn := range evalFunctions {
+			if policy == nil || policy.AllowFunction(fn) {
+				add(FunctionSuggestion, fn+"(", "", fn+"(x)")
+			}
+		}
+		add(KeywordSuggestion, "not", "", "not x")
+	}
+	return out
+}
+
+// viablePrefix check input is a query or a prefix of a query, the parser failed only at the end
+func viablePrefix(s string) bool {
+	p := &MiniQueryPeg{Tree: &Tree{}, Buffer: s}
+	if err := p.Init(); err != nil {
+		return false
+	}
+	err := p.Parse()
+	if err == nil {
+		return true
+	}
+	var pe *parseError
+	return errors.As(err, &pe) && int(pe.max.end) >= len([]rune(s))
+}
+
+// wordRange range of word at cursor, a word is name like Profile.age, symbolic operator or quoted string
+func wordRange(s string, cursor int) (int, int) {
+	if q := openQuote(s[:cursor]); q >= 0 {
+		end := cursor
+		if i := strings.IndexByte(s[cursor:], s[q]); i >= 0 {
+			end = cursor + i + 1
+		}
+		return q, end
+	}
+	class := isNameByte
+	if cursor > 0 && isOperatorByte(s[cursor-1]) || cursor < len(s) && isOperatorByte(s[cursor]) && (cursor == 0 || !isNameByte(s[cursor-1])) {
+		class = isOperatorByte
+	}
+	start, end := cursor, cursor
+	for start > 0 && class(s[start-1]) {
+		start--
+	}
+	for end < len(s) && class(s[end]) {
+		end++
+	}
+	return start, end
+}
+
+// openQuote index of unclosed quote, -1 if none
+func openQuote(s string) int {
+	q := -1
+	for i := 0; i < len(s); i++ {
+		switch {
+		case q >= 0 && s[i] == s[q]:
+			q = -1
+		case q < 0 && (s[i] == '\'' || s[i] == '"'):
+			q = i
+		}
+	}
+	return q
+}
+
+// lastWords last n words of s, multi word operators like `not like` are one word
+func lastWords(s string, n int) []string {
+	var out []string
+	s = strings.TrimRight(s, " \t\r\n")
+	for len(out) < n && s != "" {
+		class := isNameByte
+		if isOperatorByte(s[len(s)-1]) {
+			class = isOperatorByte
+		}
+		i := len(s)
+		for i > 0 && class(s[i-1]) {
+			i--
+		}
+		if i == len(s) {
+			break
+		}
+		w := s[i:]
+		s = strings.TrimRight(s[:i], " \t\r\n")
+		if lw := strings.ToLower(w); (lw == "like" || lw == "in" || lw == "between") && strings.HasSuffix(strings.ToLower(s), "not") {
+			w = s[len(s)-3:] + " " + w
+			s = strings.TrimRight(s[:len(s)-3], " \t\r\n")
+		}
+		out = append([]string{w}, out...)
+	}
+	return out
+}
+
+func isOperatorWord(w string) bool {
+	w = NormalizeOperation(w)
+	for op, v := range operatorSyntax {
+		if w == op || w == v {
+			return !strings.HasPrefix(op, "is ")
+		}
+	}
+	return false
+}
+
+func lookupCompleteField(s *Schema, p *Policy, word string) *SchemaField {
+	if s == nil || word == "" || isOperatorByte(word[0]) {
+		return nil
+	}
+	names := strings.Split(word, ".")
+	if p != nil && !p.AllowField(names, "") {
+		return nil
+	}
+	f, err := s.Lookup(names)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+func isQuote(s string) bool {
+	return s != "" && (s[0] == '\'' || s[0] == '"')
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isOperatorByte(c byte) bool {
+	return strings.IndexByte("<>=!&|", c) >= 0
+}
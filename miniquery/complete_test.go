@@ -0,0 +1,88 @@
+package miniquery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	s := &Schema{
+		Name: "User",
+		Fields: []*SchemaField{
+			{Name: "username", Type: StringFieldType},
+			{Name: "status", Type: StringFieldType, Enum: []string{"active", "locked"}},
+			{Name: "age", Type: IntFieldType},
+			{Name: "active", Type: BoolFieldType},
+			{Name: "createdAt", Type: TimeFieldType},
+		},
+		Relations: map[string]*Schema{
+			"Profile": {Name: "Profile", Fields: []*SchemaField{{Name: "bio", Type: StringFieldType}}},
+		},
+	}
+	texts := func(v []Suggestion) []string {
+		var out []string
+		for _, s := range v {
+			out = append(out, s.Text)
+		}
+		return out
+	}
+	for _, test := range []struct {
+		Q string // | marks the cursor, default at end
+		E []string
+	}{
+		{Q: `us`, E: []string{"username"}},
+		{Q: `a`, E: []string{"and", "age", "active"}},
+		{Q: `Pro`, E: []string{"Profile.bio"}},
+		{Q: `da`, E: []string{"date("}},
+		{Q: `n`, E: []string{"now(", "not"}},
+		{Q: `age `, E: []string{"=", "!=", ">", ">=", "<", "<=", "in", "not in", "between", "not between", "is null", "is not null", "and", "or"}},
+		{Q: `createdAt b`, E: []string{"between"}},
+		{Q: `active is`, E: []string{"is true", "is not true", "is false", "is not false", "is null", "is not null"}},
+		{Q: `username l`, E: []string{"like"}},
+		{Q: `status = `, E: []string{`"active"`, `"locked"`, "date(", "now(", "has_edge(", "has("}},
+		{Q: `status = 'lo`, E: []string{`"locked"`}},
+		{Q: `active = t`, E: []string{"true"}},
+		{Q: `age > 1 a`, E: []string{"and"}},
+		{Q: `age > 1 `, E: []string{"and", "or"}},
+		{Q: `age > 1 and us|er = 1`, E: []string{"username"}},
+		{Q: `(age > 1 o`, E: []string{"or"}},
+		{Q: `age = 1 x`, E: nil},
+	} {
+		t.Run(test.Q, func(t *testing.T) {
+			q, cursor := test.Q, len(test.Q)
+			if i := strings.IndexByte(q, '|'); i >= 0 {
+				q, cursor = q[:i]+q[i+1:], i
+			}
+			assert.Equal(t, test.E, texts(Complete(q, cursor, s, nil)))
+		})
+	}
+
+	// replacement range
+	v := Complete("age > 1 and user = 1", 14, s, nil)
+	if assert.Len(t, v, 1) {
+		assert.Equal(t, Suggestion{Text: "username", Kind: FieldSuggestion, Detail: "string", Start: 12, End: 16}, v[0])
+	}
+	v = Complete("age >= 1", 5, s, nil)
+	if assert.NotEmpty(t, v) {
+		assert.Equal(t, 4, v[0].Start)
+		assert.Equal(t, 6, v[0].End)
+		assert.Equal(t, ">", v[0].Text)
+	}
+	v = Complete("status = 'lo", 12, s, nil)
+	if assert.Len(t, v, 1) {
+		assert.Equal(t, Suggestion{Text: `"locked"`, Kind: ValueSuggestion, Detail: "string", Start: 9, End: 12}, v[0])
+	}
+
+	// hidden by policy
+	policy, err := ParsePolicy(`username; age: =,>; -Profile`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"and", "age"}, texts(Complete("a", 1, s, policy)))
+	assert.Nil(t, texts(Complete("Pro", 3, s, policy)))
+	assert.Equal(t, []string{"=", ">", "and", "or"}, texts(Complete("age ", 4, s, policy)))
+	assert.Equal(t, []string{"and", "or"}, texts(Complete("status ", 7, s, policy)))
+
+	// without schema
+	assert.Equal(t, []string{"and", "or"}, texts(Complete("a = 1 ", 6, nil, nil)))
+}
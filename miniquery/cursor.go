@@ -0,0 +1,205 @@
+package miniquery
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// cursor payload, order is kept to reject cursor of other order
+type cursor struct {
+	Order  string        `json:"o"`
+	Values []cursorValue `json:"v"`
+}
+
+type cursorValue struct {
+	Type  ValueType       `json:"t"`
+	Value json.RawMessage `json:"v,omitempty"`
+}
+
+// NewCursor encode opaque cursor from order values of the last row, row is struct or map like Eval
+func NewCursor(orders []*Order, row interface{}) (string, error) {
+	root := reflect.ValueOf(row)
+	values := make([]interface{}, len(orders))
+	for i, o := range orders {
+		v, err := evalOperand(o.Field, root)
+		if err != nil {
+			return "", err
+		}
+		values[i] = v
+	}
+	return EncodeCursor(orders, values)
+}
+
+// EncodeCursor encode opaque cursor from values of order fields
+func EncodeCursor(orders []*Order, values []interface{}) (string, error) {
+	if len(values) != len(orders) {
+		return "", NewError(ErrInvalidCursor).WithExpected("value of every order field")
+	}
+	c := cursor{Order: orderKey(orders)}
+	for _, v := range values {
+		n, err := NewValue(v)
+		if err != nil {
+			return "", err
+		}
+		cv := cursorValue{Type: n.ValueType}
+		var raw interface{}
+		switch n.ValueType {
+		case NullValueType:
+		case TimeValueType:
+			raw = n.Time.Format(time.RFC3339Nano)
+		case UUIDValueType:
+			raw = fmt.Sprint(n.Value())
+		case ArrayValueType:
+			return "", NewError(ErrUnsupportedValue).WithValue(string(n.ValueType))
+		default:
+			raw = n.Value()
+		}
+		if raw != nil {
+			if cv.Value, err = json.Marshal(raw); err != nil {
+				return "", err
+			}
+		}
+		c.Values = append(c.Values, cv)
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decode cursor to value nodes of order fields, cursor of other order is rejected
+func DecodeCursor(orders []*Order, s string) ([]*Node, error) {
+	invalid := func(err error) error {
+		e := NewError(ErrInvalidCursor)
+		if err != nil {
+			e.Wrap(err)
+		}
+		return e
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	var c cursor
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(&c); err != nil {
+		return nil, invalid(err)
+	}
+	if c.Order != orderKey(orders) || len(c.Values) != len(orders) {
+		return nil, invalid(nil)
+	}
+	out := make([]*Node, len(c.Values))
+	for i, cv := range c.Values {
+		n := &Node{Type: ValueNodeType, ValueType: cv.Type}
+		switch cv.Type {
+		case NullValueType:
+		case IntValueType:
+			var v int
+			err = json.Unmarshal(cv.Value, &v)
+			n.Int = v
+		case FloatValueType:
+			err = json.Unmarshal(cv.Value, &n.Float)
+		case BooleanValueType:
+			err = json.Unmarshal(cv.Value, &n.Bool)
+		case StringValueType, UUIDValueType:
+			err = json.Unmarshal(cv.Value, &n.Str)
+		case TimeValueType:
+			var v string
+			if err = json.Unmarshal(cv.Value, &v); err == nil {
+				n.Time, err = time.Parse(time.RFC3339Nano, v)
+			}
+		default:
+			return nil, invalid(nil)
+		}
+		if err != nil {
+			return nil, invalid(err)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// Keyset build predicate selecting rows after the values in order, values are usually from DecodeCursor,
+// the row comparison is expanded so mixed directions work
+//
+//	a desc, b asc after (1, 2) -> a < 1 or (a == 1 and b > 2)
+//
+// NULL values require explicit nulls first or nulls last of the field.
+func Keyset(orders []*Order, values []*Node) (*Node, error) {
+	if len(values) != len(orders) {
+		return nil, NewError(ErrInvalidCursor).WithExpected("value of every order field")
+	}
+	var terms []*Node
+	for i, o := range orders {
+		after, err := keysetAfter(o, values[i])
+		if err != nil {
+			return nil, err
+		}
+		if after == nil {
+			continue
+		}
+		var eqs []*Node
+		for j := 0; j < i; j++ {
+			eqs = append(eqs, keysetEqual(orders[j], values[j]))
+		}
+		terms = append(terms, And(append(eqs, after)...))
+	}
+	if len(terms) == 0 {
+		// nothing after the last row
+		return &Node{Type: ValueNodeType, ValueType: BooleanValueType, Bool: false}, nil
+	}
+	return Or(terms...), nil
+}
+
+// KeysetCursor decode the cursor and build keyset predicate
+func KeysetCursor(orders []*Order, s string) (*Node, error) {
+	values, err := DecodeCursor(orders, s)
+	if err != nil {
+		return nil, err
+	}
+	return Keyset(orders, values)
+}
+
+func keysetEqual(o *Order, v *Node) *Node {
+	if v.ValueType == NullValueType {
+		return &Node{Type: PredicatesExpressionType, Left: o.Field.Clone(), Op: NewOperation(OpIsNull)}
+	}
+	return &Node{Type: CompareExpressionType, Left: o.Field.Clone(), Op: NewOperation(OpEQ), Right: v.Clone()}
+}
+
+// keysetAfter condition of field sorted after v, nil if none
+func keysetAfter(o *Order, v *Node) (*Node, error) {
+	if v.ValueType == NullValueType {
+		switch o.Nulls {
+		case NullsFirst:
+			return &Node{Type: PredicatesExpressionType, Left: o.Field.Clone(), Op: NewOperation(OpIsNotNull)}, nil
+		case NullsLast:
+			return nil, nil
+		}
+		return nil, NewError(ErrInvalidCursor).WithField(Build(o.Field)).WithExpected("nulls first or nulls last")
+	}
+	op := OpGT
+	if o.Desc {
+		op = OpLT
+	}
+	cmp := &Node{Type: CompareExpressionType, Left: o.Field.Clone(), Op: NewOperation(op), Right: v.Clone()}
+	if o.Nulls == NullsLast {
+		return Or(cmp, &Node{Type: PredicatesExpressionType, Left: o.Field.Clone(), Op: NewOperation(OpIsNull)}), nil
+	}
+	return cmp, nil
+}
+
+func orderKey(orders []*Order) string {
+	s := make([]string, len(orders))
+	for i, o := range orders {
+		s[i] = o.String()
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,118 @@
+package miniquery
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	orders, err := ParseOrder(`createdAt desc, id`)
+	require.NoError(t, err)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	id := uuid.New()
+
+	s, err := EncodeCursor(orders, []interface{}{ts, id})
+	require.NoError(t, err)
+	values, err := DecodeCursor(orders, s)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(values[0].Time))
+	assert.Equal(t, id, values[1].Value())
+
+	s, err = NewCursor(orders, map[string]interface{}{"created_at": ts, "id": 10})
+	require.NoError(t, err)
+	node, err := KeysetCursor(orders, s)
+	require.NoError(t, err)
+	assert.Equal(t, `createdAt < "2024-01-02T03:04:05.000000006Z" || (createdAt == "2024-01-02T03:04:05.000000006Z" && id > 10)`, Build(node))
+
+	other, _ := ParseOrder(`createdAt, id`)
+	_, err = DecodeCursor(other, s)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = DecodeCursor(orders, s[1:])
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = DecodeCursor(orders, "e30")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestKeyset(t *testing.T) {
+	for _, test := range []struct {
+		Order  string
+		Values []interface{}
+		E      string
+		Err    bool
+	}{
+		{Order: `a`, Values: []interface{}{1}, E: `a > 1`},
+		{Order: `a desc, b, c desc`, Values: []interface{}{1, "x", 2}, E: `a < 1 || (a == 1 && b > "x") || (a == 1 && b == "x" && c < 2)`},
+		{Order: `a nulls last, id`, Values: []interface{}{1, 2}, E: `(a > 1 || a is null) || (a == 1 && id > 2)`},
+		{Order: `a nulls last, id`, Values: []interface{}{nil, 2}, E: `(a is null && id > 2)`},
+		{Order: `a desc nulls first, id`, Values: []interface{}{nil, 2}, E: `a is not null || (a is null && id > 2)`},
+		{Order: `a, id`, Values: []interface{}{nil, 2}, Err: true},
+	} {
+		orders, err := ParseOrder(test.Order)
+		require.NoError(t, err)
+		var values []*Node
+		for _, v := range test.Values {
+			values = append(values, MustValue(v))
+		}
+		node, err := Keyset(orders, values)
+		if test.Err {
+			assert.ErrorIs(t, err, ErrInvalidCursor, test.Order)
+			continue
+		}
+		assert.NoError(t, err, test.Order)
+		assert.Equal(t, test.E, Build(node), test.Order)
+	}
+}
+
+func TestKeysetPaginate(t *testing.T) {
+	type row struct {
+		ID    int
+		Group string
+		Score *int
+	}
+	score := func(v int) *int { return &v }
+	var rows []row
+	for i := range 20 {
+		r := row{ID: i, Group: string(rune('a' + i%3))}
+		if i%4 != 0 {
+			r.Score = score(i % 5)
+		}
+		rows = append(rows, r)
+	}
+	for _, order := range []string{
+		`group desc, id`,
+		`group, score desc nulls last, id desc`,
+		`score nulls first, group desc, id`,
+	} {
+		orders, err := ParseOrder(order)
+		require.NoError(t, err)
+		all := slices.Clone(rows)
+		require.NoError(t, SortSlice(all, order))
+
+		var got []row
+		cur := ""
+		for range len(rows) {
+			page := slices.Clone(rows)
+			if cur != "" {
+				node, err := KeysetCursor(orders, cur)
+				require.NoError(t, err)
+				match, err := Compile[row](node)
+				require.NoError(t, err)
+				page = slices.DeleteFunc(page, func(r row) bool { return !match(r) })
+			}
+			require.NoError(t, SortSlice(page, order))
+			if len(page) == 0 {
+				break
+			}
+			page = page[:min(3, len(page))]
+			got = append(got, page...)
+			cur, err = NewCursor(orders, page[len(page)-1])
+			require.NoError(t, err)
+		}
+		assert.Equal(t, all, got, order)
+	}
+}
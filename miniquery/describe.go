@@ -0,0 +1,121 @@
+package miniquery
+
+import (
+	"slices"
+	"strings"
+)
+
+// OpenAPIExtension name of the OpenAPI extension property of Description
+const OpenAPIExtension = "x-miniquery"
+
+// Description machine readable filterable fields, operators and functions of a schema under a policy,
+// used as OpenAPI `x-miniquery` extension or converted to JSON Schema
+type Description struct {
+	Name      string             `json:"name,omitempty"`
+	Fields    []DescriptionField `json:"fields"`
+	Functions []string           `json:"functions,omitempty"`
+}
+
+// DescriptionField filterable field, Name is the path used in query like Profile.age
+type DescriptionField struct {
+	Name      string    `json:"name"`
+	Type      FieldType `json:"type"`
+	Nullable  bool      `json:"nullable,omitempty"`
+	Operators []OpType  `json:"operators"`
+	Enum      []string  `json:"enum,omitempty"`
+	// ToMany field of to-many relation, filtered by has_edge
+	ToMany bool `json:"toMany,omitempty"`
+}
+
+// Describe describe fields of schema allowed by policy, operators are limited by both,
+// functions are the functions supported by the backend, e.g. gormq.Functions,
+// fields of to-many relations are described only when a relation function like has_edge is supported.
+func Describe(s *Schema, p *Policy, functions []string) *Description {
+	d := &Description{Name: s.Name, Fields: []DescriptionField{}}
+	for _, fn := range functions {
+		if p == nil || p.AllowFunction(fn) {
+			d.Functions = append(d.Functions, fn)
+		}
+	}
+	hasRelation := slices.ContainsFunc(d.Functions, func(fn string) bool { return RelationFunctions[fn] })
+	walkFields(s, nil, false, func(names []string, f *SchemaField, toMany bool) bool {
+		if toMany && !hasRelation {
+			return false
+		}
+		if f == nil {
+			return p == nil || p.AllowRelation(names)
+		}
+		if p != nil && !p.AllowField(names, "") {
+			return false
+		}
+		var ops []OpType
+		for _, op := range f.AllowedOperators() {
+			if p == nil || p.AllowField(names, op) {
+				ops = append(ops, op)
+			}
+		}
+		if len(ops) == 0 {
+			return false
+		}
+		d.Fields = append(d.Fields, DescriptionField{
+			Name:      strings.Join(names, "."),
+			Type:      f.Type,
+			Nullable:  f.Nullable,
+			Operators: ops,
+			Enum:      slices.Clone(f.Enum),
+			ToMany:    toMany,
+		})
+		return false
+	})
+	return d
+}
+
+// jsonSchemaTypes JSON Schema type and format of field types
+var jsonSchemaTypes = map[FieldType][2]string{
+	StringFieldType: {"string"},
+	IntFieldType:    {"integer"},
+	FloatFieldType:  {"number"},
+	BoolFieldType:   {"boolean"},
+	TimeFieldType:   {"string", "date-time"},
+	UUIDFieldType:   {"string", "uuid"},
+}
+
+// JSONSchema JSON Schema document of an object keyed by field path,
+// operators of each property are in `x-miniquery-operators`
+func (d *Description) JSONSchema() map[string]interface{} {
+	props := map[string]interface{}{}
+	for _, f := range d.Fields {
+		prop := map[string]interface{}{
+			"x-miniquery-operators": f.Operators,
+		}
+		if t, ok := jsonSchemaTypes[f.Type]; ok {
+			prop["type"] = t[0]
+			if f.Nullable {
+				prop["type"] = []string{t[0], "null"}
+			}
+			if t[1] != "" {
+				prop["format"] = t[1]
+			}
+		}
+		if len(f.Enum) != 0 {
+			prop["enum"] = f.Enum
+		}
+		if f.ToMany {
+			prop["x-miniquery-to-many"] = true
+		}
+		props[f.Name] = prop
+	}
+	out := map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if d.Name != "" {
+		out["title"] = d.Name
+	}
+	if len(d.Functions) != 0 {
+		out["x-miniquery-functions"] = d.Functions
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package miniquery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	s := &Schema{
+		Name: "User",
+		Fields: []*SchemaField{
+			{Name: "username", Type: StringFieldType},
+			{Name: "password", Type: StringFieldType},
+			{Name: "status", Type: StringFieldType, Enum: []string{"active", "locked"}, Operators: []OpType{OpEQ, OpIn}},
+			{Name: "createdAt", Type: TimeFieldType, Nullable: true},
+		},
+		Relations: map[string]*Schema{
+			"Profile": {Name: "Profile", Fields: []*SchemaField{{Name: "age", Type: IntFieldType}}},
+			"Orders":  {Name: "Order", Fields: []*SchemaField{{Name: "total", Type: FloatFieldType}}},
+		},
+		ToMany: []string{"Orders"},
+	}
+
+	d := Describe(s, nil, []string{"date", "has_edge"})
+	var names []string
+	for _, f := range d.Fields {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"username", "password", "status", "createdAt", "Orders.total", "Profile.age"}, names)
+	assert.True(t, d.Fields[4].ToMany)
+	assert.Equal(t, []OpType{OpEQ, OpIn, OpIsNull, OpIsNotNull}, d.Fields[2].Operators)
+
+	// to-many relations need relation function
+	d = Describe(s, nil, []string{"date"})
+	assert.Len(t, d.Fields, 5)
+
+	p, err := ParsePolicy("username: eq, like; status; createdAt: between; Profile.*; -password")
+	assert.NoError(t, err)
+	d = Describe(s, p, []string{"date", "now", "has_edge"})
+	assert.Equal(t, &Description{
+		Name: "User",
+		Fields: []DescriptionField{
+			{Name: "username", Type: StringFieldType, Operators: []OpType{OpEQ, OpLike}},
+			{Name: "status", Type: StringFieldType, Operators: []OpType{OpEQ, OpIn, OpIsNull, OpIsNotNull}, Enum: []string{"active", "locked"}},
+			{Name: "createdAt", Type: TimeFieldType, Nullable: true, Operators: []OpType{OpBetween, OpNotBetween}},
+			{Name: "Profile.age", Type: IntFieldType, Operators: s.Relations["Profile"].Fields[0].AllowedOperators()},
+		},
+		Functions: []string{"date", "now", "has_edge"},
+	}, d)
+
+	b, err := json.Marshal(d.JSONSchema())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "User",
+  "type": "object",
+  "additionalProperties": false,
+  "x-miniquery-functions": ["date", "now", "has_edge"],
+  "properties": {
+    "username": {"type": "string", "x-miniquery-operators": ["eq", "like"]},
+    "status": {"type": "string", "enum": ["active", "locked"], "x-miniquery-operators": ["eq", "in", "is null", "is not null"]},
+    "createdAt": {"type": ["string", "null"], "format": "date-time", "x-miniquery-operators": ["between", "not between"]},
+    "Profile.age": {"type": "integer", "x-miniquery-operators": ["eq", "neq", "gt", "gte", "lt", "lte", "in", "not in", "between", "not between", "is null", "is not null"]}
+  }
+}`, string(b))
+}
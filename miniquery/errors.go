@@ -0,0 +1,251 @@
+package miniquery
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrorCode stable code of query error, can be matched by errors.Is
+//
+//	if errors.Is(err, miniquery.ErrUnknownField) { ... }
+type ErrorCode string
+
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+const (
+	ErrSyntax               ErrorCode = "syntax"
+	ErrUnknownField         ErrorCode = "unknown_field"
+	ErrUnknownRelation      ErrorCode = "unknown_relation"
+	ErrUnsupportedFunction  ErrorCode = "unsupported_function"
+	ErrFunctionNotAllowed   ErrorCode = "function_not_allowed"
+	ErrInvalidArguments     ErrorCode = "invalid_arguments"
+	ErrInvalidEdgeArgs      ErrorCode = "invalid_edge_args"
+	ErrUnsupportedOperator  ErrorCode = "unsupported_operator"
+	ErrOperatorNotAllowed   ErrorCode = "operator_not_allowed"
+	ErrTypeMismatch         ErrorCode = "type_mismatch"
+	ErrIncomparable         ErrorCode = "incomparable"
+	ErrInvalidValue         ErrorCode = "invalid_value"
+	ErrInvalidOperand       ErrorCode = "invalid_operand"
+	ErrNotCondition         ErrorCode = "not_condition"
+	ErrUnboundParameter     ErrorCode = "unbound_parameter"
+	ErrInvalidVirtual       ErrorCode = "invalid_virtual"
+	ErrVirtualNotCondition  ErrorCode = "virtual_not_condition"
+	ErrRecursiveVirtual     ErrorCode = "recursive_virtual"
+	ErrInvalidMapping       ErrorCode = "invalid_mapping"
+	ErrInvalidPolicy        ErrorCode = "invalid_policy"
+	ErrInvalidOrder         ErrorCode = "invalid_order"
+	ErrUnsortableField      ErrorCode = "unsortable_field"
+	ErrInvalidCursor        ErrorCode = "invalid_cursor"
+	ErrNonUniqueOrder       ErrorCode = "non_unique_order"
+	ErrInvalidParameter     ErrorCode = "invalid_parameter"
+	ErrLimitExceeded        ErrorCode = "limit_exceeded"
+	ErrUnsupportedValue     ErrorCode = "unsupported_value"
+	ErrUnsupportedReference ErrorCode = "unsupported_reference"
+	ErrInvalidNode          ErrorCode = "invalid_node"
+	ErrMissingModel         ErrorCode = "missing_model"
+
+	// MsgDidYouMean message appended when error has suggestions
+	MsgDidYouMean ErrorCode = "did_you_mean"
+)
+
+// Error query error with code and the context
+type Error struct {
+	Code     ErrorCode
+	Field    string // field or relation path
+	Function string
+	Operator OpType
+	Value    string // offending value, token or expression
+	Expected string
+	Offset   int // byte offset in query, -1 if unknown
+	// Suggestions similar names of unknown field, relation or function
+	Suggestions []string
+	Err         error
+}
+
+// NewError create error of code without position
+func NewError(code ErrorCode) *Error {
+	return &Error{Code: code, Offset: -1}
+}
+
+func (e *Error) WithField(names ...string) *Error {
+	e.Field = strings.Join(names, ".")
+	return e
+}
+
+func (e *Error) WithFunction(name string) *Error {
+	e.Function = name
+	return e
+}
+
+func (e *Error) WithOperator(op OpType) *Error {
+	e.Operator = op
+	return e
+}
+
+func (e *Error) WithValue(v string) *Error {
+	e.Value = v
+	return e
+}
+
+func (e *Error) WithExpected(v string) *Error {
+	e.Expected = v
+	return e
+}
+
+// WithSuggestions attach names similar to name picked from candidates
+func (e *Error) WithSuggestions(name string, candidates []string) *Error {
+	e.Suggestions = Suggest(name, candidates)
+	return e
+}
+
+func (e *Error) WithOffset(offset int) *Error {
+	e.Offset = offset
+	return e
+}
+
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// Error message in DefaultLanguage
+func (e *Error) Error() string {
+	return e.Message(DefaultLanguage)
+}
+
+// Message localized message, language like zh-CN fallback to zh then DefaultLanguage
+func (e *Error) Message(lang string) string {
+	format := lookupMessage(lang, e.Code)
+	if format == "" {
+		format = string(e.Code)
+	}
+	msg := format
+	// arguments are referenced by index, unused arguments are not reported
+	if strings.Contains(format, "%[") {
+		msg = fmt.Sprintf(format, e.Field, e.Function, string(e.Operator), e.Value, e.Expected, e.Offset)
+	}
+	if len(e.Suggestions) != 0 {
+		quoted := make([]string, len(e.Suggestions))
+		for i, v := range e.Suggestions {
+			quoted[i] = strconv.Quote(v)
+		}
+		msg += fmt.Sprintf(lookupMessage(lang, MsgDidYouMean), strings.Join(quoted, ", "))
+	}
+	if e.Err != nil {
+		msg += ": " + Localize(e.Err, lang)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is match ErrorCode
+func (e *Error) Is(target error) bool {
+	c, ok := target.(ErrorCode)
+	return ok && c == e.Code
+}
+
+// Localize return localized message of the query error in err chain, other errors use Error()
+func Localize(err error, lang string) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Message(lang)
+	}
+	return err.Error()
+}
+
+// DefaultLanguage language of Error()
+var DefaultLanguage = "en"
+
+// Messages message catalog of language to code to format, arguments are referenced by index
+//
+//	%[1] field, %[2] function, %[3] operator, %[4] value, %[5] expected, %[6] offset
+var Messages = map[string]map[ErrorCode]string{
+	"en": {
+		ErrSyntax:               "invalid query syntax near %[4]q",
+		ErrUnknownField:         "field not found: %[1]q",
+		ErrUnknownRelation:      "relation not found: %[1]q",
+		ErrUnsupportedFunction:  "unsupported function: %[2]q",
+		ErrFunctionNotAllowed:   "function not allowed: %[2]q",
+		ErrInvalidArguments:     "invalid arguments of function %[2]q, expected %[5]s",
+		ErrInvalidEdgeArgs:      "invalid relation arguments: %[4]s",
+		ErrUnsupportedOperator:  "unsupported operator %[3]q",
+		ErrOperatorNotAllowed:   "operator %[3]q not allowed for field %[1]q",
+		ErrTypeMismatch:         "type mismatch: %[5]s field %[1]q can not compare with %[4]s",
+		ErrIncomparable:         "can not compare %[5]s with %[4]s",
+		ErrInvalidValue:         "invalid value %[4]q for field %[1]q, expected one of %[5]s",
+		ErrInvalidOperand:       "invalid operand of %[3]q: %[4]s",
+		ErrNotCondition:         "not a condition: %[4]s",
+		ErrUnboundParameter:     "unbound parameter: %[4]q",
+		ErrInvalidVirtual:       "invalid virtual field %[1]q",
+		ErrVirtualNotCondition:  "virtual field %[1]q can only be used as condition",
+		ErrRecursiveVirtual:     "recursive virtual field: %[4]s",
+		ErrInvalidMapping:       "field %[1]q resolved to invalid path %[4]q",
+		ErrInvalidPolicy:        "invalid policy entry: %[4]q",
+		ErrInvalidOrder:         "invalid order: %[4]q",
+		ErrUnsortableField:      "field can not be sorted: %[1]q",
+		ErrInvalidCursor:        "invalid cursor",
+		ErrNonUniqueOrder:       "order %[4]q is not unique for cursor, add %[5]s",
+		ErrInvalidParameter:     "invalid parameter %[1]q: %[4]q, expected %[5]s",
+		ErrLimitExceeded:        "query %[1]s %[4]s exceeds limit %[5]s",
+		ErrUnsupportedValue:     "unsupported value type %[4]s",
+		ErrUnsupportedReference: "unsupported reference: %[1]q",
+		ErrInvalidNode:          "invalid node type %[4]q",
+		ErrMissingModel:         "missing model",
+		MsgDidYouMean:           ", did you mean %s?",
+	},
+	"zh": {
+		ErrSyntax:               "查询语法错误，附近: %[4]q",
+		ErrUnknownField:         "字段不存在: %[1]q",
+		ErrUnknownRelation:      "不存在关联: %[1]q",
+		ErrUnsupportedFunction:  "不支持的函数: %[2]q",
+		ErrFunctionNotAllowed:   "不允许使用函数: %[2]q",
+		ErrInvalidArguments:     "函数 %[2]q 参数错误，需要 %[5]s",
+		ErrInvalidEdgeArgs:      "关联参数错误: %[4]s",
+		ErrUnsupportedOperator:  "不支持的操作符 %[3]q",
+		ErrOperatorNotAllowed:   "字段 %[1]q 不允许使用操作符 %[3]q",
+		ErrTypeMismatch:         "类型不匹配: %[5]s 字段 %[1]q 不能与 %[4]s 比较",
+		ErrIncomparable:         "无法比较 %[5]s 和 %[4]s",
+		ErrInvalidValue:         "字段 %[1]q 的值 %[4]q 无效，可选值: %[5]s",
+		ErrInvalidOperand:       "操作符 %[3]q 的操作数无效: %[4]s",
+		ErrNotCondition:         "不是条件表达式: %[4]s",
+		ErrUnboundParameter:     "参数未绑定: %[4]q",
+		ErrInvalidVirtual:       "虚拟字段定义错误 %[1]q",
+		ErrVirtualNotCondition:  "虚拟字段 %[1]q 只能作为条件使用",
+		ErrRecursiveVirtual:     "虚拟字段循环引用: %[4]s",
+		ErrInvalidMapping:       "字段 %[1]q 映射到无效路径 %[4]q",
+		ErrInvalidPolicy:        "权限策略错误: %[4]q",
+		ErrInvalidOrder:         "排序错误: %[4]q",
+		ErrUnsortableField:      "字段不支持排序: %[1]q",
+		ErrInvalidCursor:        "无效的游标",
+		ErrNonUniqueOrder:       "排序 %[4]q 不唯一，无法使用游标，请加入 %[5]s",
+		ErrInvalidParameter:     "参数 %[1]q 无效: %[4]q，需要 %[5]s",
+		ErrLimitExceeded:        "查询 %[1]s 为 %[4]s，超出限制 %[5]s",
+		ErrUnsupportedValue:     "不支持的值类型 %[4]s",
+		ErrUnsupportedReference: "不支持的引用: %[1]q",
+		ErrInvalidNode:          "无效的节点类型 %[4]q",
+		ErrMissingModel:         "缺少模型",
+		MsgDidYouMean:           "，是否要使用 %s？",
+	},
+}
+
+func lookupMessage(lang string, code ErrorCode) string {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	for lang != "" {
+		if v := Messages[lang][code]; v != "" {
+			return v
+		}
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	return Messages[DefaultLanguage][code]
+}
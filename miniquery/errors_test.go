@@ -0,0 +1,53 @@
+package miniquery
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntaxError(t *testing.T) {
+	for _, test := range []struct {
+		Q      string
+		Offset int
+		Near   string
+	}{
+		{Q: `a = 1 and`, Offset: 9, Near: ""},
+		{Q: `name = 'wener' and age >`, Offset: 24, Near: ""},
+		{Q: `名字 = 1`, Offset: 0, Near: "名字 = 1"},
+		{Q: `a = '名' b`, Offset: 10, Near: "b"},
+	} {
+		_, err := Parse(test.Q)
+		assert.ErrorIs(t, err, ErrSyntax, test.Q)
+		var e *Error
+		if assert.ErrorAs(t, err, &e) {
+			assert.Equal(t, test.Offset, e.Offset, test.Q)
+			assert.Equal(t, test.Near, e.Value, test.Q)
+		}
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewError(ErrUnknownField).WithField("Profile", "age"))
+	assert.ErrorIs(t, err, ErrUnknownField)
+	assert.NotErrorIs(t, err, ErrUnknownRelation)
+	assert.Equal(t, `wrapped: field not found: "Profile.age"`, err.Error())
+	assert.Equal(t, `字段不存在: "Profile.age"`, Localize(err, "zh-CN"))
+	assert.Equal(t, `field not found: "Profile.age"`, Localize(err, "fr"))
+	assert.Equal(t, "other", Localize(errors.New("other"), "zh"))
+
+	assert.Equal(t, "missing model", NewError(ErrMissingModel).Error())
+	assert.Equal(t, `operator "like" not allowed for field "age"`, NewError(ErrOperatorNotAllowed).WithField("age").WithOperator(OpLike).Error())
+	assert.Equal(t, `invalid virtual field "x": invalid query syntax near ""`, NewError(ErrInvalidVirtual).WithField("x").Wrap(NewError(ErrSyntax)).Error())
+
+	// every code has message in every language
+	for lang, messages := range Messages {
+		assert.Len(t, messages, len(Messages[DefaultLanguage]), lang)
+	}
+
+	v := &Virtuals{}
+	assert.ErrorIs(t, v.Define("bad", `a =`), ErrSyntax)
+	assert.ErrorIs(t, v.Define("bad", `a =`), ErrInvalidVirtual)
+}
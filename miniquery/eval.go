@@ -0,0 +1,697 @@
+package miniquery
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Eval evaluate the query against struct, map or pointer to them.
+//
+// Fields are matched by name, json tag or snake case name, nested fields are accessed by reference like Profile.age.
+// NULL follow SQL three-valued logic, comparing with NULL is unknown and unknown is treated as false at the end.
+// like is case-insensitive for ASCII like SQLite.
+func Eval(node *Node, value interface{}) (bool, error) {
+	if node == nil {
+		return true, nil
+	}
+	t, err := evalCondition(node, reflect.ValueOf(value))
+	return t == triTrue, err
+}
+
+// tri SQL three-valued logic
+type tri int8
+
+const (
+	triFalse   tri = 0
+	triTrue    tri = 1
+	triUnknown tri = -1
+)
+
+func triOf(b bool) tri {
+	if b {
+		return triTrue
+	}
+	return triFalse
+}
+
+func (t tri) not() tri {
+	switch t {
+	case triTrue:
+		return triFalse
+	case triFalse:
+		return triTrue
+	}
+	return triUnknown
+}
+
+func (t tri) and(o tri) tri {
+	switch {
+	case t == triFalse || o == triFalse:
+		return triFalse
+	case t == triTrue && o == triTrue:
+		return triTrue
+	}
+	return triUnknown
+}
+
+func (t tri) or(o tri) tri {
+	switch {
+	case t == triTrue || o == triTrue:
+		return triTrue
+	case t == triFalse && o == triFalse:
+		return triFalse
+	}
+	return triUnknown
+}
+
+func evalCondition(node *Node, root reflect.Value) (tri, error) {
+	if t, ok, err := evalToMany(node, root); ok {
+		return t, err
+	}
+	switch node.Type {
+	case ParenthesesExpressionType:
+		return evalCondition(node.Expression, root)
+	case NotExpressionType:
+		t, err := evalCondition(node.Expression, root)
+		return t.not(), err
+	case LogicExpressionType:
+		l, err := evalCondition(node.Left, root)
+		if err != nil {
+			return l, err
+		}
+		r, err := evalCondition(node.Right, root)
+		if err != nil {
+			return r, err
+		}
+		if node.Op.Operation == OpOr {
+			return l.or(r), nil
+		}
+		return l.and(r), nil
+	case CompareExpressionType:
+		l, err := evalOperand(node.Left, root)
+		if err != nil {
+			return triUnknown, err
+		}
+		r, err := evalOperand(node.Right, root)
+		if err != nil {
+			return triUnknown, err
+		}
+		return compareOp(node.Op.Operation, l, r)
+	case BetweenExpressionType:
+		v, err := evalOperand(node.Left, root)
+		if err != nil {
+			return triUnknown, err
+		}
+		lo, err := evalOperand(node.Params[0], root)
+		if err != nil {
+			return triUnknown, err
+		}
+		hi, err := evalOperand(node.Params[1], root)
+		if err != nil {
+			return triUnknown, err
+		}
+		return between(node.Op.Operation, v, lo, hi)
+	case PredicatesExpressionType:
+		v, err := evalOperand(node.Left, root)
+		if err != nil {
+			return triUnknown, err
+		}
+		return predicate(node.Op.Operation, v)
+	case FunctionExpressionType:
+		if RelationFunctions[node.Name] {
+			return evalHasEdge(node, root)
+		}
+	case ParameterNodeType:
+		return triUnknown, NewError(ErrUnboundParameter).WithValue(node.Name)
+	}
+	// bare value or field as condition
+	v, err := evalOperand(node, root)
+	if err != nil {
+		return triUnknown, err
+	}
+	return truth(v)
+}
+
+func truth(v interface{}) (tri, error) {
+	switch v := v.(type) {
+	case nil:
+		return triUnknown, nil
+	case bool:
+		return triOf(v), nil
+	case int64:
+		return triOf(v != 0), nil
+	case float64:
+		return triOf(v != 0), nil
+	}
+	return triUnknown, NewError(ErrNotCondition).WithValue(fmt.Sprintf("%T", v))
+}
+
+func evalOperand(node *Node, root reflect.Value) (interface{}, error) {
+	switch node.Type {
+	case ValueNodeType:
+		if node.ValueType == ArrayValueType {
+			out := make([]interface{}, len(node.Array))
+			for i, v := range node.Array {
+				out[i] = normalizeValue(reflect.ValueOf(v.Value()))
+			}
+			return out, nil
+		}
+		return normalizeValue(reflect.ValueOf(node.Value())), nil
+	case IdentifierNodeType:
+		v, err := lookupPath(root, []string{node.Name})
+		return normalizeValue(v), err
+	case ReferenceNodeType:
+		v, err := lookupPath(root, node.Names)
+		return normalizeValue(v), err
+	case FunctionExpressionType:
+		return evalFunction(node, root)
+	case ParameterNodeType:
+		return nil, NewError(ErrUnboundParameter).WithValue(node.Name)
+	}
+	t, err := evalCondition(node, root)
+	if err != nil || t == triUnknown {
+		return nil, err
+	}
+	return t == triTrue, nil
+}
+
+func evalFunction(node *Node, root reflect.Value) (interface{}, error) {
+	switch node.Name {
+	case "now":
+		return time.Now(), nil
+	case "date":
+		if len(node.Params) != 1 {
+			return nil, NewError(ErrInvalidArguments).WithFunction(node.Name).WithExpected("1 argument")
+		}
+		v, err := evalOperand(node.Params[0], root)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		t, ok := toTime(v)
+		if !ok {
+			return nil, NewError(ErrInvalidArguments).WithFunction(node.Name).WithExpected("time").WithValue(fmt.Sprintf("%T", v))
+		}
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location()), nil
+	case "has_edge", "has":
+		t, err := evalHasEdge(node, root)
+		return t == triTrue, err
+	}
+	return nil, NewError(ErrUnsupportedFunction).WithFunction(node.Name).WithSuggestions(node.Name, evalFunctions)
+}
+
+// evalHasEdge match any element of slice relation or the non-nil relation
+func evalHasEdge(node *Node, root reflect.Value) (tri, error) {
+	params := node.Params
+	if len(params) == 0 || len(params) > 2 || params[0].Type != IdentifierNodeType {
+		return triUnknown, NewError(ErrInvalidEdgeArgs).WithFunction(node.Name).WithValue(Build(node))
+	}
+	rel, err := lookupPath(root, []string{params[0].Name})
+	if err != nil {
+		return triUnknown, err
+	}
+	rel = indirect(rel)
+	match := func(v reflect.Value) (tri, error) {
+		if len(params) == 1 {
+			return triTrue, nil
+		}
+		return evalCondition(params[1], v)
+	}
+	if !rel.IsValid() {
+		return triFalse, nil
+	}
+	if rel.Kind() != reflect.Slice && rel.Kind() != reflect.Array {
+		return match(rel)
+	}
+	for i := 0; i < rel.Len(); i++ {
+		t, err := match(rel.Index(i))
+		if err != nil || t == triTrue {
+			return t, err
+		}
+	}
+	return triFalse, nil
+}
+
+// evalToMany match condition on field through slice relation like `Orders.total > 100` against any element,
+// same as the EXISTS of gormq, ok is false if the field is not through a slice
+func evalToMany(node *Node, root reflect.Value) (t tri, ok bool, err error) {
+	if node = referenceOnLeft(node); node == nil {
+		return triUnknown, false, nil
+	}
+	names := node.Left.Names
+	v := root
+	for i, name := range names[:len(names)-1] {
+		if v = indirect(v); !v.IsValid() {
+			return triUnknown, false, nil
+		}
+		next, found := lookupField(v, name)
+		if !found {
+			return triUnknown, false, nil
+		}
+		v = indirect(next)
+		if !isSlice(v) {
+			continue
+		}
+		sub := *node
+		sub.Left = &Node{Type: ReferenceNodeType, Names: names[i+1:]}
+		for j := 0; j < v.Len(); j++ {
+			t, err = evalCondition(&sub, v.Index(j))
+			if err != nil || t == triTrue {
+				return t, true, err
+			}
+		}
+		return triFalse, true, nil
+	}
+	return triUnknown, false, nil
+}
+
+// flippedOps operator of comparison with operands swapped
+var flippedOps = map[OpType]OpType{
+	OpGT: OpLT, OpGTE: OpLTE, OpLT: OpGT, OpLTE: OpGTE, OpEQ: OpEQ, OpNEQ: OpNEQ,
+}
+
+// referenceOnLeft return condition with reference field on left which may go through slice relation,
+// `100 < Orders.total` is swapped to `Orders.total > 100`, nil if there is no such field
+func referenceOnLeft(node *Node) *Node {
+	switch node.Type {
+	case CompareExpressionType:
+		if node.Left.Type != ReferenceNodeType && node.Right.Type == ReferenceNodeType {
+			op, ok := flippedOps[node.Op.Operation]
+			if !ok {
+				return nil
+			}
+			c := *node
+			c.Left, c.Right = node.Right, node.Left
+			c.Op = &Node{Type: OperationNodeType, Operation: op}
+			return &c
+		}
+	case BetweenExpressionType, PredicatesExpressionType:
+	default:
+		return nil
+	}
+	if node.Left == nil || node.Left.Type != ReferenceNodeType {
+		return nil
+	}
+	return node
+}
+
+func isSlice(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	}
+	return false
+}
+
+func compareOp(op OpType, l, r interface{}) (tri, error) {
+	switch op {
+	case OpIn, OpNotIn:
+		t, err := in(l, r)
+		if op == OpNotIn {
+			t = t.not()
+		}
+		return t, err
+	case OpLike, OpNotLike:
+		if l == nil || r == nil {
+			return triUnknown, nil
+		}
+		pattern, ok := r.(string)
+		if !ok {
+			return triUnknown, NewError(ErrInvalidOperand).WithOperator(op).WithValue(fmt.Sprintf("%T", r))
+		}
+		t := triOf(CompileLike(pattern).MatchString(toString(l)))
+		if op == OpNotLike {
+			t = t.not()
+		}
+		return t, nil
+	}
+	if l == nil || r == nil {
+		return triUnknown, nil
+	}
+	c, err := compareValues(l, r)
+	if err != nil {
+		return triUnknown, err
+	}
+	switch op {
+	case OpEQ:
+		return triOf(c == 0), nil
+	case OpNEQ:
+		return triOf(c != 0), nil
+	case OpGT:
+		return triOf(c > 0), nil
+	case OpGTE:
+		return triOf(c >= 0), nil
+	case OpLT:
+		return triOf(c < 0), nil
+	case OpLTE:
+		return triOf(c <= 0), nil
+	}
+	return triUnknown, NewError(ErrUnsupportedOperator).WithOperator(op)
+}
+
+func in(v, list interface{}) (tri, error) {
+	values, ok := list.([]interface{})
+	if !ok {
+		return triUnknown, NewError(ErrInvalidOperand).WithOperator(OpIn).WithValue(fmt.Sprintf("%T", list))
+	}
+	if v == nil {
+		if len(values) == 0 {
+			return triFalse, nil
+		}
+		return triUnknown, nil
+	}
+	out := triFalse
+	for _, e := range values {
+		if e == nil {
+			out = triUnknown
+			continue
+		}
+		c, err := compareValues(v, e)
+		if err != nil {
+			return triUnknown, err
+		}
+		if c == 0 {
+			return triTrue, nil
+		}
+	}
+	return out, nil
+}
+
+func between(op OpType, v, lo, hi interface{}) (tri, error) {
+	l, err := compareOp(OpGTE, v, lo)
+	if err != nil {
+		return l, err
+	}
+	h, err := compareOp(OpLTE, v, hi)
+	if err != nil {
+		return h, err
+	}
+	t := l.and(h)
+	if op == OpNotBetween {
+		t = t.not()
+	}
+	return t, nil
+}
+
+func predicate(op OpType, v interface{}) (tri, error) {
+	switch op {
+	case OpIsNull:
+		return triOf(v == nil), nil
+	case OpIsNotNull:
+		return triOf(v != nil), nil
+	}
+	t, err := truth(v)
+	if err != nil {
+		return t, err
+	}
+	switch op {
+	case OpIsTrue:
+		return triOf(t == triTrue), nil
+	case OpIsNotTrue:
+		return triOf(t != triTrue), nil
+	case OpIsFalse:
+		return triOf(t == triFalse), nil
+	case OpIsNotFalse:
+		return triOf(t != triFalse), nil
+	}
+	return triUnknown, NewError(ErrUnsupportedOperator).WithOperator(op)
+}
+
+// compareValues compare normalized values, numeric strings and time strings are converted like SQLite affinity
+func compareValues(a, b interface{}) (int, error) {
+	switch av := a.(type) {
+	case int64:
+		switch bv := b.(type) {
+		case int64:
+			return cmp(av, bv), nil
+		case float64:
+			return cmp(float64(av), bv), nil
+		case bool:
+			return cmp(av, boolInt(bv)), nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(bv), 64); err == nil {
+				return cmp(float64(av), f), nil
+			}
+		}
+	case float64:
+		switch bv := b.(type) {
+		case int64:
+			return cmp(av, float64(bv)), nil
+		case float64:
+			return cmp(av, bv), nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(bv), 64); err == nil {
+				return cmp(av, f), nil
+			}
+		}
+	case bool:
+		switch bv := b.(type) {
+		case bool:
+			return cmp(boolInt(av), boolInt(bv)), nil
+		case int64:
+			return cmp(boolInt(av), bv), nil
+		}
+	case string:
+		switch bv := b.(type) {
+		case string:
+			return strings.Compare(av, bv), nil
+		case int64, float64:
+			c, err := compareValues(b, a)
+			return -c, err
+		case time.Time:
+			if t, ok := ParseTime(av); ok {
+				return t.Compare(bv), nil
+			}
+		}
+	case time.Time:
+		if bt, ok := toTime(b); ok {
+			return av.Compare(bt), nil
+		}
+	}
+	return 0, NewError(ErrIncomparable).WithExpected(fmt.Sprintf("%T", a)).WithValue(fmt.Sprintf("%T", b))
+}
+
+func cmp[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		return ParseTime(v)
+	}
+	return time.Time{}, false
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// CompileLike compile SQL like pattern to regexp, % match any sequence and _ match single character, case-insensitive,
+// \ escape the next character
+func CompileLike(pattern string) *regexp.Regexp {
+	buf := strings.Builder{}
+	buf.WriteString("(?is)^")
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		case c == '\\':
+			escaped = true
+		case c == '%':
+			buf.WriteString(".*")
+		case c == '_':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	buf.WriteString("$")
+	return regexp.MustCompile(buf.String())
+}
+
+// EscapeLike escape %, _ and \ to match s literally in like pattern
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+var (
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// normalizeValue convert to nil, int64, float64, bool, string, time.Time or the original value
+func normalizeValue(v reflect.Value) interface{} {
+	if v.IsValid() && v.Type() != timeType && v.Type().Implements(valuerType) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil
+		}
+		dv, err := v.Interface().(driver.Valuer).Value()
+		if err != nil {
+			return nil
+		}
+		v = reflect.ValueOf(dv)
+	}
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == timeType {
+		return v.Interface()
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().Type().Implements(valuerType) {
+		return normalizeValue(v.Addr())
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(v.Uint()) //nolint:gosec
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+	}
+	return v.Interface()
+}
+
+// lookupPath access nested field of struct or map, missing map key is NULL
+func lookupPath(root reflect.Value, names []string) (reflect.Value, error) {
+	v := root
+	for i, name := range names {
+		v = indirect(v)
+		if !v.IsValid() {
+			return v, nil
+		}
+		next, ok := lookupField(v, name)
+		if !ok {
+			return next, NewError(ErrUnknownField).WithField(names[:i+1]...).WithSuggestions(name, structFieldNames(v.Type()))
+		}
+		v = next
+	}
+	return v, nil
+}
+
+func lookupField(v reflect.Value, name string) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		key := reflect.ValueOf(name).Convert(v.Type().Key())
+		if out := v.MapIndex(key); out.IsValid() {
+			return out, true
+		}
+		folded := foldName(name)
+		iter := v.MapRange()
+		for iter.Next() {
+			if foldName(iter.Key().String()) == folded {
+				return iter.Value(), true
+			}
+		}
+		return reflect.Value{}, true
+	case reflect.Struct:
+		index, ok := structFieldIndex(v.Type(), name)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		out, err := v.FieldByIndexErr(index)
+		if err != nil {
+			// nil embedded pointer
+			return reflect.Value{}, true
+		}
+		return out, true
+	}
+	return reflect.Value{}, false
+}
+
+// evalFunctions functions supported by Eval and Compile
+var evalFunctions = []string{"date", "now", "has_edge", "has"}
+
+// structFieldNames names and json tags of exported fields for suggestions
+func structFieldNames(t reflect.Type) []string {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var out []string
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous || f.Tag.Get("json") == "-" {
+			continue
+		}
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" && tag != "-" {
+			out = append(out, tag)
+		}
+		out = append(out, f.Name)
+	}
+	return out
+}
+
+// structFieldIndex find exported field by name, json tag or folded name
+func structFieldIndex(t reflect.Type, name string) ([]int, bool) {
+	folded := foldName(name)
+	var found []int
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous || f.Tag.Get("json") == "-" {
+			continue
+		}
+		if f.Name == name {
+			return f.Index, true
+		}
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == name {
+			return f.Index, true
+		}
+		if found == nil && (foldName(f.Name) == folded || (tag != "" && foldName(tag) == folded)) {
+			found = f.Index
+		}
+	}
+	return found, found != nil
+}
@@ -0,0 +1,154 @@
+package miniquery
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type evalProfile struct {
+	Age  int
+	Bio  *string
+	Tags []string
+}
+
+type evalUser struct {
+	ID        int
+	Username  string `json:"name"`
+	CreatedAt time.Time
+	Active    bool
+	Score     sql.NullFloat64
+	Profile   *evalProfile
+	Orders    []evalOrder
+	Password  string `json:"-"`
+}
+
+type evalOrder struct {
+	Amount float64
+	Status string
+}
+
+func TestEval(t *testing.T) {
+	bio := "Go developer"
+	user := evalUser{
+		ID:        1,
+		Username:  "wener",
+		CreatedAt: time.Date(2021, 5, 12, 10, 0, 0, 0, time.UTC),
+		Active:    true,
+		Profile:   &evalProfile{Age: 18, Bio: &bio},
+		Orders:    []evalOrder{{Amount: 10, Status: "done"}, {Amount: 99.5, Status: "open"}},
+		Password:  "secret",
+	}
+	for _, test := range []struct {
+		Q   string
+		E   bool
+		Err bool
+	}{
+		{Q: `id = 1`, E: true},
+		{Q: `name = 'wener' and username = 'wener'`, E: true},
+		{Q: `user_name = 'wener'`, E: true},
+		{Q: `Profile.age >= 18 and Profile.age < '19'`, E: true},
+		{Q: `Profile.age between 1 and 17`},
+		{Q: `Profile.age not between 1 and 17`, E: true},
+		{Q: `id in (1, 2)`, E: true},
+		{Q: `id not in (2, null)`},
+		{Q: `id in (2, null)`},
+		{Q: `not id in (2, null)`},
+		{Q: `name like 'WE%'`, E: true},
+		{Q: `name like 'w_ner'`, E: true},
+		{Q: `name not like '%x%'`, E: true},
+		{Q: `Profile.bio like '%go%'`, E: true},
+		{Q: `created_at > '2021-05-12'`, E: true},
+		{Q: `date(created_at) = '2021-05-12'`, E: true},
+		{Q: `created_at < now()`, E: true},
+		{Q: `active`, E: true},
+		{Q: `active is true and active is not false`, E: true},
+		{Q: `active = false`},
+		// NULL
+		{Q: `score is null`, E: true},
+		{Q: `score > 1`},
+		{Q: `not score > 1`},
+		{Q: `score > 1 or id = 1`, E: true},
+		{Q: `score is not true`, E: true},
+		{Q: `score is false`},
+		// relation
+		{Q: `has_edge(Orders)`, E: true},
+		{Q: `has_edge(Orders, amount > 50 and status = 'open')`, E: true},
+		{Q: `has_edge(Orders, amount > 100)`},
+		{Q: `has_edge(Profile, age = 18)`, E: true},
+		{Q: `has(Orders, amount > 100)`},
+		{Q: `not has(Orders, amount > 100)`, E: true},
+		{Q: `Orders.amount > 50`, E: true},
+		{Q: `Orders.amount > 100`},
+		{Q: `not Orders.amount > 100`, E: true},
+		{Q: `100 > Orders.amount`, E: true},
+		{Q: `99.5 <= Orders.amount and 'open' = Orders.status`, E: true},
+		{Q: `100 < Orders.amount`},
+		{Q: `Orders.status = 'open' and Orders.amount between 1 and 20`, E: true},
+		{Q: `Orders.status is null`},
+		{Q: `Orders.missing = 1`, Err: true},
+		{Q: `missing = 1`, Err: true},
+		{Q: `password = 'secret'`, Err: true},
+		{Q: `unknown(id)`, Err: true},
+		{Q: `name > 1`, Err: true},
+	} {
+		node, err := Parse(test.Q)
+		assert.NoError(t, err, test.Q)
+		ok, err := Eval(node, user)
+		if test.Err {
+			assert.Error(t, err, test.Q)
+			continue
+		}
+		assert.NoError(t, err, test.Q)
+		assert.Equal(t, test.E, ok, test.Q)
+
+		ok, err = Eval(node, &user)
+		assert.NoError(t, err, test.Q)
+		assert.Equal(t, test.E, ok, test.Q)
+	}
+}
+
+func TestEvalMap(t *testing.T) {
+	v := map[string]interface{}{
+		"action": "push",
+		"repo": map[string]interface{}{
+			"name":  "go-miniquery",
+			"stars": 10,
+		},
+		"commits": []interface{}{
+			map[string]interface{}{"message": "fix parser"},
+		},
+	}
+	for _, test := range []struct {
+		Q string
+		E bool
+	}{
+		{Q: `action = 'push' and repo.stars > 5`, E: true},
+		{Q: `repo.name like '%query'`, E: true},
+		{Q: `repo.owner is null`, E: true},
+		{Q: `repo.owner = 'wener'`},
+		{Q: `sender.login = 'wener'`},
+		{Q: `has_edge(commits, message like 'fix%')`, E: true},
+		{Q: `commits.message like 'fix%'`, E: true},
+		{Q: `commits.message = 'init'`},
+	} {
+		node, err := Parse(test.Q)
+		assert.NoError(t, err, test.Q)
+		ok, err := Eval(node, v)
+		assert.NoError(t, err, test.Q)
+		assert.Equal(t, test.E, ok, test.Q)
+	}
+}
+
+func TestCompileLike(t *testing.T) {
+	assert.True(t, CompileLike(`a.b%`).MatchString("A.Bcd"))
+	assert.False(t, CompileLike(`a.b%`).MatchString("axb"))
+	assert.True(t, CompileLike(`_%_`).MatchString("ab"))
+	assert.False(t, CompileLike(`_%_`).MatchString("a"))
+	assert.True(t, CompileLike(`50\%\_%`).MatchString("50%_off"))
+	assert.False(t, CompileLike(`50\%\_%`).MatchString("500_off"))
+	assert.True(t, CompileLike(`%`+EscapeLike(`C:\d_r`)).MatchString(`x C:\d_r`))
+	assert.False(t, CompileLike(`%`+EscapeLike(`C:\d_r`)).MatchString(`x C:\dir`))
+}
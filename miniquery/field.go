@@ -0,0 +1,293 @@
+package miniquery
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Field typed filter handle, used by code generated with miniquery-gen
+//
+//	UserFilter.Profile.Age.Gt(18) // Profile.Age > 18
+type Field struct {
+	Names []string
+}
+
+// NewField create a field handle for name under the relation path prefix
+func NewField(prefix []string, name string) Field {
+	names := make([]string, 0, len(prefix)+1)
+	names = append(names, prefix...)
+	names = append(names, name)
+	return Field{Names: names}
+}
+
+// Path return the prefix for fields of a relation
+func (f Field) Path() []string {
+	return f.Names
+}
+
+// Node return the identifier or reference node of this field
+func (f Field) Node() *Node {
+	if len(f.Names) == 1 {
+		return &Node{Type: IdentifierNodeType, Name: f.Names[0]}
+	}
+	names := make([]string, len(f.Names))
+	copy(names, f.Names)
+	return &Node{Type: ReferenceNodeType, Names: names}
+}
+
+func (f Field) String() string {
+	return Build(f.Node())
+}
+
+func (f Field) IsNull() *Node {
+	return f.predicate(OpIsNull)
+}
+
+func (f Field) IsNotNull() *Node {
+	return f.predicate(OpIsNotNull)
+}
+
+func (f Field) predicate(op OpType) *Node {
+	return &Node{
+		Type: PredicatesExpressionType,
+		Left: f.Node(),
+		Op:   NewOperation(op),
+	}
+}
+
+func (f Field) compare(op OpType, v interface{}) *Node {
+	return &Node{
+		Type:  CompareExpressionType,
+		Left:  f.Node(),
+		Op:    NewOperation(op),
+		Right: MustValue(v),
+	}
+}
+
+func (f Field) between(op OpType, lo, hi interface{}) *Node {
+	return &Node{
+		Type:   BetweenExpressionType,
+		Left:   f.Node(),
+		Op:     NewOperation(op),
+		Params: []*Node{MustValue(lo), MustValue(hi)},
+	}
+}
+
+// StringField handle for string fields
+type StringField struct {
+	Field
+}
+
+func (f StringField) Eq(v string) *Node        { return f.compare(OpEQ, v) }
+func (f StringField) Neq(v string) *Node       { return f.compare(OpNEQ, v) }
+func (f StringField) Like(v string) *Node      { return f.compare(OpLike, v) }
+func (f StringField) NotLike(v string) *Node   { return f.compare(OpNotLike, v) }
+func (f StringField) In(v ...string) *Node     { return f.compare(OpIn, v) }
+func (f StringField) NotIn(v ...string) *Node  { return f.compare(OpNotIn, v) }
+func (f StringField) Gt(v string) *Node        { return f.compare(OpGT, v) }
+func (f StringField) Gte(v string) *Node       { return f.compare(OpGTE, v) }
+func (f StringField) Lt(v string) *Node        { return f.compare(OpLT, v) }
+func (f StringField) Lte(v string) *Node       { return f.compare(OpLTE, v) }
+func (f StringField) Contains(v string) *Node  { return f.Like("%" + EscapeLike(v) + "%") }
+func (f StringField) HasPrefix(v string) *Node { return f.Like(EscapeLike(v) + "%") }
+func (f StringField) HasSuffix(v string) *Node { return f.Like("%" + EscapeLike(v)) }
+
+// IntField handle for integer fields
+type IntField struct {
+	Field
+}
+
+func (f IntField) Eq(v int) *Node              { return f.compare(OpEQ, v) }
+func (f IntField) Neq(v int) *Node             { return f.compare(OpNEQ, v) }
+func (f IntField) Gt(v int) *Node              { return f.compare(OpGT, v) }
+func (f IntField) Gte(v int) *Node             { return f.compare(OpGTE, v) }
+func (f IntField) Lt(v int) *Node              { return f.compare(OpLT, v) }
+func (f IntField) Lte(v int) *Node             { return f.compare(OpLTE, v) }
+func (f IntField) In(v ...int) *Node           { return f.compare(OpIn, v) }
+func (f IntField) NotIn(v ...int) *Node        { return f.compare(OpNotIn, v) }
+func (f IntField) Between(lo, hi int) *Node    { return f.between(OpBetween, lo, hi) }
+func (f IntField) NotBetween(lo, hi int) *Node { return f.between(OpNotBetween, lo, hi) }
+func (f IntField) IsZero() *Node               { return f.Eq(0) }
+
+// FloatField handle for floating point fields
+type FloatField struct {
+	Field
+}
+
+func (f FloatField) Eq(v float64) *Node              { return f.compare(OpEQ, v) }
+func (f FloatField) Neq(v float64) *Node             { return f.compare(OpNEQ, v) }
+func (f FloatField) Gt(v float64) *Node              { return f.compare(OpGT, v) }
+func (f FloatField) Gte(v float64) *Node             { return f.compare(OpGTE, v) }
+func (f FloatField) Lt(v float64) *Node              { return f.compare(OpLT, v) }
+func (f FloatField) Lte(v float64) *Node             { return f.compare(OpLTE, v) }
+func (f FloatField) Between(lo, hi float64) *Node    { return f.between(OpBetween, lo, hi) }
+func (f FloatField) NotBetween(lo, hi float64) *Node { return f.between(OpNotBetween, lo, hi) }
+
+// BoolField handle for boolean fields
+type BoolField struct {
+	Field
+}
+
+func (f BoolField) Eq(v bool) *Node   { return f.compare(OpEQ, v) }
+func (f BoolField) IsTrue() *Node     { return f.predicate(OpIsTrue) }
+func (f BoolField) IsFalse() *Node    { return f.predicate(OpIsFalse) }
+func (f BoolField) IsNotTrue() *Node  { return f.predicate(OpIsNotTrue) }
+func (f BoolField) IsNotFalse() *Node { return f.predicate(OpIsNotFalse) }
+
+// TimeField handle for time fields, values are formatted as RFC3339
+type TimeField struct {
+	Field
+}
+
+func (f TimeField) Eq(v time.Time) *Node              { return f.compare(OpEQ, v) }
+func (f TimeField) Neq(v time.Time) *Node             { return f.compare(OpNEQ, v) }
+func (f TimeField) Gt(v time.Time) *Node              { return f.compare(OpGT, v) }
+func (f TimeField) Gte(v time.Time) *Node             { return f.compare(OpGTE, v) }
+func (f TimeField) Lt(v time.Time) *Node              { return f.compare(OpLT, v) }
+func (f TimeField) Lte(v time.Time) *Node             { return f.compare(OpLTE, v) }
+func (f TimeField) Before(v time.Time) *Node          { return f.Lt(v) }
+func (f TimeField) After(v time.Time) *Node           { return f.Gt(v) }
+func (f TimeField) Between(lo, hi time.Time) *Node    { return f.between(OpBetween, lo, hi) }
+func (f TimeField) NotBetween(lo, hi time.Time) *Node { return f.between(OpNotBetween, lo, hi) }
+
+// And combine expressions by and, nil expressions are skipped
+func And(nodes ...*Node) *Node {
+	return logic(OpAnd, nodes)
+}
+
+// Or combine expressions by or, nil expressions are skipped
+func Or(nodes ...*Node) *Node {
+	return logic(OpOr, nodes)
+}
+
+// Not negate the expression
+func Not(node *Node) *Node {
+	return &Node{Type: NotExpressionType, Expression: parentheses(node)}
+}
+
+func logic(op OpType, nodes []*Node) *Node {
+	var out *Node
+	for _, v := range nodes {
+		if v == nil {
+			continue
+		}
+		if v.Type == LogicExpressionType {
+			v = parentheses(v)
+		}
+		if out == nil {
+			out = v
+			continue
+		}
+		out = &Node{
+			Type:  LogicExpressionType,
+			Left:  out,
+			Op:    NewOperation(op),
+			Right: v,
+		}
+	}
+	return out
+}
+
+func parentheses(node *Node) *Node {
+	if !node.IsExpression() || node.Type == ParenthesesExpressionType || node.Type == FunctionExpressionType {
+		return node
+	}
+	return &Node{Type: ParenthesesExpressionType, Expression: node}
+}
+
+// NewOperation create operation node
+func NewOperation(op OpType) *Node {
+	return &Node{Type: OperationNodeType, Operation: op}
+}
+
+// NewValue create value node from go value
+func NewValue(v interface{}) (*Node, error) {
+	n := &Node{Type: ValueNodeType}
+	switch v := v.(type) {
+	case nil:
+		n.ValueType = NullValueType
+	case *Node:
+		return v, nil
+	case int:
+		n.ValueType, n.Int = IntValueType, v
+	case int8:
+		n.ValueType, n.Int = IntValueType, int(v)
+	case int16:
+		n.ValueType, n.Int = IntValueType, int(v)
+	case int32:
+		n.ValueType, n.Int = IntValueType, int(v)
+	case int64:
+		n.ValueType, n.Int = IntValueType, int(v)
+	case uint:
+		if uint64(v) > math.MaxInt {
+			return nil, NewError(ErrUnsupportedValue).WithValue(fmt.Sprintf("%T %v overflows int", v, v))
+		}
+		n.ValueType, n.Int = IntValueType, int(v)
+	case uint8:
+		n.ValueType, n.Int = IntValueType, int(v)
+	case uint16:
+		n.ValueType, n.Int = IntValueType, int(v)
+	case uint32:
+		n.ValueType, n.Int = IntValueType, int(v)
+	case uint64:
+		if v > math.MaxInt {
+			return nil, NewError(ErrUnsupportedValue).WithValue(fmt.Sprintf("%T %v overflows int", v, v))
+		}
+		n.ValueType, n.Int = IntValueType, int(v)
+	case float32:
+		return NewValue(float64(v))
+	case float64:
+		// no literal of NaN and infinity
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, NewError(ErrUnsupportedValue).WithValue(fmt.Sprintf("%T %v", v, v))
+		}
+		n.ValueType, n.Float = FloatValueType, v
+	case bool:
+		n.ValueType, n.Bool = BooleanValueType, v
+	case string:
+		n.ValueType, n.Str = StringValueType, v
+	case time.Time:
+		n.ValueType, n.Time = TimeValueType, v
+	case uuid.UUID:
+		n.ValueType, n.Str, n.UUID = UUIDValueType, v.String(), v
+	case fmt.Stringer:
+		n.ValueType, n.Str = StringValueType, v.String()
+	case []interface{}:
+		return newArray(len(v), func(i int) interface{} { return v[i] })
+	case []string:
+		return newArray(len(v), func(i int) interface{} { return v[i] })
+	case []int:
+		return newArray(len(v), func(i int) interface{} { return v[i] })
+	case []int64:
+		return newArray(len(v), func(i int) interface{} { return v[i] })
+	case []float64:
+		return newArray(len(v), func(i int) interface{} { return v[i] })
+	default:
+		return nil, NewError(ErrUnsupportedValue).WithValue(fmt.Sprintf("%T", v))
+	}
+	return n, nil
+}
+
+// MustValue like NewValue but panic on error
+func MustValue(v interface{}) *Node {
+	n, err := NewValue(v)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func newArray(n int, get func(i int) interface{}) (*Node, error) {
+	out := &Node{Type: ValueNodeType, ValueType: ArrayValueType, Array: make([]*Node, 0, n)}
+	for i := 0; i < n; i++ {
+		v, err := NewValue(get(i))
+		if err != nil {
+			return nil, err
+		}
+		out.Array = append(out.Array, v)
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package miniquery
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestField(t *testing.T) {
+	username := StringField{Field: NewField(nil, "Username")}
+	age := IntField{Field: NewField([]string{"Profile"}, "Age")}
+	active := BoolField{Field: NewField(nil, "Active")}
+	created := TimeField{Field: NewField(nil, "CreatedAt")}
+	at := time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		N *Node
+		E string
+	}{
+		{N: username.Eq("wener"), E: `Username == "wener"`},
+		{N: age.Gt(18), E: `Profile.Age > 18`},
+		{N: age.Between(1, 10), E: `Profile.Age between 1 and 10`},
+		{N: username.In("a", "b"), E: `Username in ["a","b"]`},
+		{N: active.IsTrue(), E: `Active is true`},
+		{N: active.IsNotFalse(), E: `Active is not false`},
+		{N: username.IsNull(), E: `Username is null`},
+		{N: created.Gte(at), E: `CreatedAt >= "2021-05-12T00:00:00Z"`},
+		{N: And(username.Eq("wener"), nil, Or(age.Lt(1), age.Gt(2))), E: `Username == "wener" && (Profile.Age < 1 || Profile.Age > 2)`},
+		{N: Not(username.Contains("x")), E: `not (Username like "%x%")`},
+		{N: username.Gte("a"), E: `Username >= "a"`},
+		{N: username.Lte("b"), E: `Username <= "b"`},
+		{N: username.HasPrefix(`50%_off\`), E: `Username like "50\%\_off\\%"`},
+		{N: username.Eq(`C:\dir`), E: `Username == "C:\dir"`},
+		{N: username.Eq(`a"b`), E: `Username == 'a"b'`},
+	} {
+		s := Build(test.N)
+		assert.Equal(t, test.E, s)
+		_, err := Parse(s)
+		assert.NoError(t, err, s)
+	}
+
+	n, err := NewValue(uint64(math.MaxInt))
+	assert.NoError(t, err)
+	assert.Equal(t, math.MaxInt, n.Int)
+	_, err = NewValue(uint64(math.MaxUint64))
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+	_, err = NewValue(uint(math.MaxUint))
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+}
+
+func TestFieldRoundTrip(t *testing.T) {
+	username := StringField{Field: NewField(nil, "Username")}
+	for _, v := range []string{`C:\dir`, "tab\there", `a"b`, `it's "x"`, `50%_off`} {
+		for _, test := range []struct {
+			N     *Node
+			Match func(s string) bool
+		}{
+			{N: username.Eq(v), Match: func(s string) bool { return s == v }},
+			{N: username.In(v, "x"), Match: func(s string) bool { return s == v || s == "x" }},
+			{N: username.Contains(v), Match: func(s string) bool { return strings.Contains(s, v) }},
+			{N: username.HasSuffix(v), Match: func(s string) bool { return strings.HasSuffix(s, v) }},
+		} {
+			q := Build(test.N)
+			n, err := Parse(q)
+			if !assert.NoError(t, err, q) {
+				continue
+			}
+			assert.Equal(t, test.N.Right, n.Right, q)
+			for _, s := range []string{v, "x" + v, v + "x", "x"} {
+				ok, err := Eval(n, map[string]interface{}{"Username": s})
+				assert.NoError(t, err)
+				assert.Equal(t, test.Match(s), ok, "%s on %s", q, s)
+			}
+		}
+	}
+}
+
+func TestFieldNumberRoundTrip(t *testing.T) {
+	age := IntField{Field: NewField(nil, "Age")}
+	score := FloatField{Field: NewField(nil, "Score")}
+	for _, test := range []struct {
+		N     *Node
+		E     string
+		Match func(v float64) bool
+	}{
+		{N: age.Gt(-1), E: `Age > -1`, Match: func(v float64) bool { return v > -1 }},
+		{N: age.In(-2, 0), E: `Age in [-2,0]`, Match: func(v float64) bool { return v == -2 || v == 0 }},
+		{N: score.Gt(1.5), E: `Score > 1.5`, Match: func(v float64) bool { return v > 1.5 }},
+		{N: score.Eq(2.0), E: `Score == 2.0`, Match: func(v float64) bool { return v == 2 }},
+		{N: score.Lt(-0.25), E: `Score < -0.25`, Match: func(v float64) bool { return v < -0.25 }},
+		{N: score.Between(-1.5, 1e21), E: `Score between -1.5 and 1000000000000000000000.0`, Match: func(v float64) bool { return v >= -1.5 }},
+	} {
+		q := Build(test.N)
+		assert.Equal(t, test.E, q)
+		n, err := Parse(q)
+		if !assert.NoError(t, err, q) {
+			continue
+		}
+		assert.Equal(t, Build(n), q)
+		if test.N.Right != nil {
+			assert.Equal(t, test.N.Right, n.Right, q)
+		}
+		for _, v := range []float64{-2, -1, -0.5, 0, 1.5, 2, 3} {
+			var value interface{} = v
+			if test.N.Left.Name == "Age" {
+				if v != math.Trunc(v) {
+					continue
+				}
+				value = int(v)
+			}
+			ok, err := Eval(n, map[string]interface{}{"Age": value, "Score": value})
+			assert.NoError(t, err)
+			assert.Equal(t, test.Match(v), ok, "%s on %v", q, value)
+		}
+	}
+
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := NewValue(v)
+		assert.ErrorIs(t, err, ErrUnsupportedValue)
+	}
+	n, err := NewValue(float32(0.5))
+	assert.NoError(t, err)
+	assert.Equal(t, FloatValueType, n.ValueType)
+}
@@ -0,0 +1,115 @@
+package miniquery
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Filter filter the sequence by query, the query is parsed and compiled before iteration
+//
+//	seq, err := miniquery.Filter(maps.Values(flags), `enabled and name like 'beta%'`)
+func Filter[T any](seq iter.Seq[T], query string) (iter.Seq[T], error) {
+	match, err := compileQuery[T](query)
+	if err != nil || match == nil {
+		return seq, err
+	}
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if match(v) && !yield(v) {
+				return
+			}
+		}
+	}, nil
+}
+
+// FilterSlice return the elements matched by query and sorted by order, empty order keep the original order
+func FilterSlice[T any](s []T, query string, order string) ([]T, error) {
+	match, err := compileQuery[T](query)
+	if err != nil {
+		return nil, err
+	}
+	cmp, err := compileOrder[T](order)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		if match == nil || match(v) {
+			out = append(out, v)
+		}
+	}
+	if cmp != nil {
+		slices.SortStableFunc(out, cmp)
+	}
+	return out, nil
+}
+
+// SortSlice stable sort the slice in place by order string, NULL sort first in ascending order like SQLite
+func SortSlice[T any](s []T, order string) error {
+	cmp, err := compileOrder[T](order)
+	if err != nil || cmp == nil {
+		return err
+	}
+	slices.SortStableFunc(s, cmp)
+	return nil
+}
+
+// compileQuery return nil predicate for empty query
+func compileQuery[T any](query string) (func(T) bool, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+	node, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return Compile[T](node)
+}
+
+// compileOrder return nil compare function for empty order
+func compileOrder[T any](order string) (func(a, b T) int, error) {
+	orders, err := ParseOrder(order)
+	if err != nil || len(orders) == 0 {
+		return nil, err
+	}
+	t := reflect.TypeFor[T]()
+	keys := make([]compiledOperand, len(orders))
+	for i, o := range orders {
+		if keys[i], err = compileOperand(o.Field, t); err != nil {
+			return nil, err
+		}
+	}
+	return func(a, b T) int {
+		av, bv := reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem()
+		for i, o := range orders {
+			if c := compareOrder(o, keys[i](av), keys[i](bv)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}, nil
+}
+
+// compareOrder compare by direction, NULL is the smallest unless Nulls is set, incomparable values are equal
+func compareOrder(o *Order, a, b interface{}) int {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return 0
+		}
+		first := o.Nulls == NullsFirst || (o.Nulls == "" && !o.Desc)
+		if (a == nil) == first {
+			return -1
+		}
+		return 1
+	}
+	c, err := compareValues(a, b)
+	if err != nil {
+		return 0
+	}
+	if o.Desc {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,73 @@
+package miniquery
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type featureFlag struct {
+	Name    string
+	Enabled bool
+	Rollout *int
+}
+
+func TestFilter(t *testing.T) {
+	ten, fifty := 10, 50
+	flags := map[string]featureFlag{
+		"beta-ui":   {Name: "beta-ui", Enabled: true, Rollout: &fifty},
+		"beta-api":  {Name: "beta-api", Enabled: true},
+		"dark-mode": {Name: "dark-mode", Enabled: false, Rollout: &ten},
+	}
+	seq, err := Filter(maps.Values(flags), `enabled and name like 'beta%'`)
+	assert.NoError(t, err)
+	names := slices.Sorted(func(yield func(string) bool) {
+		for v := range seq {
+			if !yield(v.Name) {
+				return
+			}
+		}
+	})
+	assert.Equal(t, []string{"beta-api", "beta-ui"}, names)
+
+	seq, err = Filter(maps.Values(flags), ``)
+	assert.NoError(t, err)
+	assert.Len(t, slices.Collect(seq), 3)
+
+	_, err = Filter(maps.Values(flags), `enabled and`)
+	assert.Error(t, err)
+	_, err = Filter(maps.Values(flags), `missing = 1`)
+	assert.Error(t, err)
+
+	all := slices.Collect(maps.Values(flags))
+	out, err := FilterSlice(all, `name != 'x'`, `rollout desc, name`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"beta-ui", "dark-mode", "beta-api"}, flagNames(out))
+
+	out, err = FilterSlice(all, `rollout > 1`, `name desc`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dark-mode", "beta-ui"}, flagNames(out))
+
+	assert.NoError(t, SortSlice(all, `rollout, name`))
+	assert.Equal(t, []string{"beta-api", "dark-mode", "beta-ui"}, flagNames(all))
+	assert.Error(t, SortSlice(all, `missing`))
+	assert.Error(t, SortSlice(all, `name sideways`))
+	_, err = FilterSlice(all, ``, `a = 1`)
+	assert.Error(t, err)
+
+	rows := []map[string]any{{"n": 1, "v": 2}, {"n": 2}, {"n": 3, "v": 1}}
+	assert.NoError(t, SortSlice(rows, `v nulls last`))
+	assert.Equal(t, []any{3, 1, 2}, []any{rows[0]["n"], rows[1]["n"], rows[2]["n"]})
+	assert.NoError(t, SortSlice(rows, `v desc nulls first`))
+	assert.Equal(t, []any{2, 1, 3}, []any{rows[0]["n"], rows[1]["n"], rows[2]["n"]})
+}
+
+func flagNames(flags []featureFlag) []string {
+	out := make([]string, len(flags))
+	for i, v := range flags {
+		out[i] = v.Name
+	}
+	return out
+}
@@ -0,0 +1,207 @@
+package miniquery
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListRequest list parameters of query, order, cursor or offset pagination and projection
+//
+//	?q=age > 18&order=createdAt desc&limit=20&offset=40&fields=id,username
+type ListRequest struct {
+	Query  string
+	Order  string
+	Cursor string
+	Limit  int
+	Offset int
+	// Fields projection, empty select all
+	Fields []string
+}
+
+// ListOptions limits and defaults of ParseListRequest
+type ListOptions struct {
+	DefaultLimit int // used when limit is absent, default 20
+	MaxLimit     int // larger limit is capped, default 100
+	MaxOffset    int // larger offset is rejected, 0 no limit
+	DefaultOrder string
+	// Schema validate query, order and fields when not nil, skip when public names are mapped by resolver
+	Schema *Schema
+	// Policy restrict query, order and fields before validating, hidden fields are not suggested,
+	// names are canonicalized by Schema
+	Policy *Policy
+}
+
+// DefaultListOptions used when ParseListRequest options is nil
+var DefaultListOptions = ListOptions{DefaultLimit: 20, MaxLimit: 100}
+
+// list request parameter names
+const (
+	ListParamQuery  = "q"
+	ListParamOrder  = "order"
+	ListParamCursor = "cursor"
+	ListParamLimit  = "limit"
+	ListParamOffset = "offset"
+	ListParamFields = "fields"
+)
+
+// ParseListRequest parse list request from url query, fields can be comma separated or repeated
+func ParseListRequest(values url.Values, opts *ListOptions) (*ListRequest, error) {
+	if opts == nil {
+		opts = &DefaultListOptions
+	}
+	r := &ListRequest{
+		Query:  strings.TrimSpace(values.Get(ListParamQuery)),
+		Order:  strings.TrimSpace(values.Get(ListParamOrder)),
+		Cursor: strings.TrimSpace(values.Get(ListParamCursor)),
+	}
+	if r.Order == "" {
+		r.Order = opts.DefaultOrder
+	}
+
+	var err error
+	if r.Limit, err = parseListInt(values, ListParamLimit); err != nil {
+		return nil, err
+	}
+	if r.Limit == 0 {
+		r.Limit = opts.DefaultLimit
+		if r.Limit == 0 {
+			r.Limit = DefaultListOptions.DefaultLimit
+		}
+	}
+	maxLimit := opts.MaxLimit
+	if maxLimit == 0 {
+		maxLimit = DefaultListOptions.MaxLimit
+	}
+	r.Limit = min(r.Limit, maxLimit)
+
+	if r.Offset, err = parseListInt(values, ListParamOffset); err != nil {
+		return nil, err
+	}
+	if opts.MaxOffset > 0 && r.Offset > opts.MaxOffset {
+		return nil, NewError(ErrInvalidParameter).WithField(ListParamOffset).WithValue(strconv.Itoa(r.Offset)).WithExpected("at most " + strconv.Itoa(opts.MaxOffset))
+	}
+	if r.Cursor != "" && r.Offset != 0 {
+		return nil, NewError(ErrInvalidParameter).WithField(ListParamOffset).WithValue(strconv.Itoa(r.Offset)).WithExpected("no offset with cursor")
+	}
+
+	for _, v := range values[ListParamFields] {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				r.Fields = append(r.Fields, f)
+			}
+		}
+	}
+
+	policy := opts.Policy.WithSchema(opts.Schema)
+	if err = r.Enforce(policy); err != nil {
+		return nil, err
+	}
+	if opts.Schema != nil {
+		if err = r.Validate(opts.Schema); err != nil {
+			return nil, policy.Redact(err)
+		}
+	}
+	return r, nil
+}
+
+func parseListInt(values url.Values, name string) (int, error) {
+	s := strings.TrimSpace(values.Get(name))
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, NewError(ErrInvalidParameter).WithField(name).WithValue(s).WithExpected("non-negative integer")
+	}
+	return n, nil
+}
+
+// Enforce check query, order and fields only use fields allowed by policy, nil policy allow all
+func (r *ListRequest) Enforce(p *Policy) error {
+	if p == nil {
+		return nil
+	}
+	if r.Query != "" {
+		ast, err := Parse(r.Query)
+		if err != nil {
+			return err
+		}
+		if err = p.Enforce(ast); err != nil {
+			return err
+		}
+	}
+	orders, err := ParseOrder(r.Order)
+	if err != nil {
+		return err
+	}
+	for _, o := range orders {
+		if err = p.Enforce(o.Field); err != nil {
+			return err
+		}
+	}
+	for _, f := range r.Fields {
+		if names := strings.Split(f, "."); !p.AllowField(names, "") {
+			return NewError(ErrUnknownField).WithField(names...)
+		}
+	}
+	return nil
+}
+
+// Validate check query, order and fields against schema
+func (r *ListRequest) Validate(schema *Schema) error {
+	if r.Query != "" {
+		ast, err := Parse(r.Query)
+		if err != nil {
+			return err
+		}
+		if err = Check(ast, schema); err != nil {
+			return err
+		}
+	}
+	orders, err := ParseOrder(r.Order)
+	if err != nil {
+		return err
+	}
+	if err = CheckOrder(orders, schema); err != nil {
+		return err
+	}
+	if r.Cursor != "" {
+		if err = CheckKeyset(orders, schema); err != nil {
+			return err
+		}
+		if _, err = DecodeCursor(orders, r.Cursor); err != nil {
+			return err
+		}
+	}
+	for _, f := range r.Fields {
+		if strings.Contains(f, ".") {
+			return NewError(ErrUnsupportedReference).WithField(f)
+		}
+		if _, err = schema.Lookup([]string{f}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Values encode the request to url query, can be used to build next page link
+func (r *ListRequest) Values() url.Values {
+	v := url.Values{}
+	set := func(k, s string) {
+		if s != "" {
+			v.Set(k, s)
+		}
+	}
+	set(ListParamQuery, r.Query)
+	set(ListParamOrder, r.Order)
+	set(ListParamCursor, r.Cursor)
+	if r.Limit > 0 {
+		v.Set(ListParamLimit, strconv.Itoa(r.Limit))
+	}
+	if r.Offset > 0 {
+		v.Set(ListParamOffset, strconv.Itoa(r.Offset))
+	}
+	set(ListParamFields, strings.Join(r.Fields, ","))
+	return v
+}
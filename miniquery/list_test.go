@@ -0,0 +1,70 @@
+package miniquery
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListRequest(t *testing.T) {
+	schema := &Schema{
+		Fields: []*SchemaField{
+			{Name: "id", Type: IntFieldType, Unique: true},
+			{Name: "username", Type: StringFieldType},
+			{Name: "createdAt", Column: "created_at", Type: TimeFieldType},
+		},
+	}
+	opts := &ListOptions{DefaultLimit: 10, MaxLimit: 50, MaxOffset: 1000, DefaultOrder: "id desc", Schema: schema}
+	for _, test := range []struct {
+		Q   string
+		E   *ListRequest
+		Err error
+	}{
+		{Q: ``, E: &ListRequest{Order: "id desc", Limit: 10}},
+		{
+			Q: `q=username+like+'w%25'&order=createdAt+desc,+id&limit=500&offset=20&fields=id,username&fields=created_at`,
+			E: &ListRequest{Query: "username like 'w%'", Order: "createdAt desc, id", Limit: 50, Offset: 20, Fields: []string{"id", "username", "created_at"}},
+		},
+		{Q: `limit=-1`, Err: ErrInvalidParameter},
+		{Q: `limit=ten`, Err: ErrInvalidParameter},
+		{Q: `offset=2000`, Err: ErrInvalidParameter},
+		{Q: `cursor=abc&offset=10`, Err: ErrInvalidParameter},
+		{Q: `cursor=abc`, Err: ErrInvalidCursor},
+		{Q: `order=createdAt&cursor=abc`, Err: ErrNonUniqueOrder},
+		{Q: `order=createdAt desc, username&cursor=abc`, Err: ErrNonUniqueOrder},
+		{Q: `q=usrname='a'`, Err: ErrUnknownField},
+		{Q: `q=id=`, Err: ErrSyntax},
+		{Q: `order=name`, Err: ErrUnknownField},
+		{Q: `fields=id,passwd`, Err: ErrUnknownField},
+		{Q: `fields=Profile.age`, Err: ErrUnsupportedReference},
+	} {
+		values, err := url.ParseQuery(test.Q)
+		assert.NoError(t, err)
+		r, err := ParseListRequest(values, opts)
+		if test.Err != nil {
+			assert.ErrorIs(t, err, test.Err, test.Q)
+			continue
+		}
+		assert.NoError(t, err, test.Q)
+		assert.Equal(t, test.E, r, test.Q)
+
+		again, err := ParseListRequest(r.Values(), opts)
+		assert.NoError(t, err)
+		assert.Equal(t, r, again, test.Q)
+	}
+
+	r, err := ParseListRequest(url.Values{"limit": {"1000"}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, r.Limit)
+
+	// hidden fields are rejected in query, order and fields and never suggested
+	policyOpts := &ListOptions{Schema: schema, Policy: &Policy{Deny: []string{"username"}}}
+	for _, q := range []string{`q=username = 'a'`, `order=username`, `fields=id,username`} {
+		values, _ := url.ParseQuery(q)
+		_, err = ParseListRequest(values, policyOpts)
+		assert.EqualError(t, err, `field not found: "username"`, q)
+	}
+	_, err = ParseListRequest(url.Values{"q": {`usernam = 'a'`}}, policyOpts)
+	assert.EqualError(t, err, `field not found: "usernam"`)
+}
@@ -33,15 +33,18 @@ const (
 	ruleIdentifierReference
 	ruleJsonReference
 	ruleIdentifier
+	ruleKeyword
 	ruleCompare
 	ruleLogic
 	ruleMatch
 	ruleValue
 	ruleArray
 	ruleLiteral
+	ruleFloat
 	ruleInteger
 	ruleBoolean
 	ruleNull
+	ruleParameter
 	ruleString
 	ruleSpaceComment
 	rule_
@@ -81,6 +84,8 @@ const (
 	ruleAction27
 	ruleAction28
 	ruleAction29
+	ruleAction30
+	ruleAction31
 )
 
 var rul3s = [...]string{
@@ -100,15 +105,18 @@ var rul3s = [...]string{
 	"IdentifierReference",
 	"JsonReference",
 	"Identifier",
+	"Keyword",
 	"Compare",
 	"Logic",
 	"Match",
 	"Value",
 	"Array",
 	"Literal",
+	"Float",
 	"Integer",
 	"Boolean",
 	"Null",
+	"Parameter",
 	"String",
 	"SpaceComment",
 	"_",
@@ -148,6 +156,8 @@ var rul3s = [...]string{
 	"Action27",
 	"Action28",
 	"Action29",
+	"Action30",
+	"Action31",
 }
 
 type token32 struct {
@@ -264,7 +274,7 @@ type MiniQueryPeg struct {
 
 	Buffer string
 	buffer []rune
-	rules  [64]func() bool
+	rules  [69]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -417,15 +427,19 @@ func (p *MiniQueryPeg) Execute() {
 		case ruleAction24:
 			p.PopArray()
 		case ruleAction25:
-			p.AddInteger(text)
+			p.AddFloat(text)
 		case ruleAction26:
-			p.AddBoolean(text)
+			p.AddInteger(text)
 		case ruleAction27:
-			p.AddNull()
+			p.AddBoolean(text)
 		case ruleAction28:
-			p.AddString(text)
+			p.AddNull()
 		case ruleAction29:
-			p.AddString(text)
+			p.AddParameter(text)
+		case ruleAction30:
+			p.AddString(unquote(text, '\''))
+		case ruleAction31:
+			p.AddString(unquote(text, '"'))
 
 		}
 	}
@@ -445,7 +459,6 @@ func Size(size int) func(*MiniQueryPeg) error {
 		return nil
 	}
 }
-
 func (p *MiniQueryPeg) Init(options ...func(*MiniQueryPeg) error) error {
 	var (
 		max                  token32
@@ -1359,112 +1372,70 @@ func (p *MiniQueryPeg) Init(options ...func(*MiniQueryPeg) error) error {
 			position, tokenIndex = position93, tokenIndex93
 			return false
 		},
-		/* 14 Identifier <- <(!(('n' / 'N') ('o' / 'O') ('t' / 'T')) <(([a-z] / [A-Z]) ((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('_') '_') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> Action14)> */
+		/* 14 Identifier <- <(!Keyword <(([a-z] / [A-Z]) ((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('_') '_') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> Action14)> */
 		func() bool {
 			position97, tokenIndex97 := position, tokenIndex
 			{
 				position98 := position
 				{
 					position99, tokenIndex99 := position, tokenIndex
-					{
-						position100, tokenIndex100 := position, tokenIndex
-						if buffer[position] != rune('n') {
-							goto l101
-						}
-						position++
-						goto l100
-					l101:
-						position, tokenIndex = position100, tokenIndex100
-						if buffer[position] != rune('N') {
-							goto l99
-						}
-						position++
-					}
-				l100:
-					{
-						position102, tokenIndex102 := position, tokenIndex
-						if buffer[position] != rune('o') {
-							goto l103
-						}
-						position++
-						goto l102
-					l103:
-						position, tokenIndex = position102, tokenIndex102
-						if buffer[position] != rune('O') {
-							goto l99
-						}
-						position++
-					}
-				l102:
-					{
-						position104, tokenIndex104 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l105
-						}
-						position++
-						goto l104
-					l105:
-						position, tokenIndex = position104, tokenIndex104
-						if buffer[position] != rune('T') {
-							goto l99
-						}
-						position++
+					if !_rules[ruleKeyword]() {
+						goto l99
 					}
-				l104:
 					goto l97
 				l99:
 					position, tokenIndex = position99, tokenIndex99
 				}
 				{
-					position106 := position
+					position100 := position
 					{
-						position107, tokenIndex107 := position, tokenIndex
+						position101, tokenIndex101 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l108
+							goto l102
 						}
 						position++
-						goto l107
-					l108:
-						position, tokenIndex = position107, tokenIndex107
+						goto l101
+					l102:
+						position, tokenIndex = position101, tokenIndex101
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
 							goto l97
 						}
 						position++
 					}
-				l107:
-				l109:
+				l101:
+				l103:
 					{
-						position110, tokenIndex110 := position, tokenIndex
+						position104, tokenIndex104 := position, tokenIndex
 						{
 							switch buffer[position] {
 							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l110
+									goto l104
 								}
 								position++
 							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 								if c := buffer[position]; c < rune('A') || c > rune('Z') {
-									goto l110
+									goto l104
 								}
 								position++
 							case '_':
 								if buffer[position] != rune('_') {
-									goto l110
+									goto l104
 								}
 								position++
 							default:
 								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l110
+									goto l104
 								}
 								position++
 							}
 						}
 
-						goto l109
-					l110:
-						position, tokenIndex = position110, tokenIndex110
+						goto l103
+					l104:
+						position, tokenIndex = position104, tokenIndex104
 					}
-					add(rulePegText, position106)
+					add(rulePegText, position100)
 				}
 				if !_rules[ruleAction14]() {
 					goto l97
@@ -1476,229 +1447,285 @@ func (p *MiniQueryPeg) Init(options ...func(*MiniQueryPeg) error) error {
 			position, tokenIndex = position97, tokenIndex97
 			return false
 		},
-		/* 15 Compare <- <((_ <(('>' '=') / ('<' '=') / ('=' '=') / '<' / ((&('=') '=') | (&('<') ('<' '>')) | (&('>') '>') | (&('!') ('!' '='))))> _ Action15) / (_ <((('g' / 'G') ('t' / 'T')) / (('l' / 'L') ('t' / 'T')) / ((&('N' | 'n') (('n' / 'N') ('e' / 'E') ('q' / 'Q'))) | (&('E' | 'e') (('e' / 'E') ('q' / 'Q'))) | (&('L' | 'l') (('l' / 'L') ('t' / 'T') ('e' / 'E'))) | (&('G' | 'g') (('g' / 'G') ('t' / 'T') ('e' / 'E')))))> _ Action16) / (_ <((('l' / 'L') ('i' / 'I') ('k' / 'K') ('e' / 'E')) / (('n' / 'N') ('o' / 'O') ('t' / 'T') __ (('l' / 'L') ('i' / 'I') ('k' / 'K') ('e' / 'E'))))> _ Action17))> */
+		/* 15 Keyword <- <(('n' / 'N') ('o' / 'O') ('t' / 'T') !((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('_') '_') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z])))> */
 		func() bool {
-			position112, tokenIndex112 := position, tokenIndex
+			position106, tokenIndex106 := position, tokenIndex
 			{
-				position113 := position
+				position107 := position
+				{
+					position108, tokenIndex108 := position, tokenIndex
+					if buffer[position] != rune('n') {
+						goto l109
+					}
+					position++
+					goto l108
+				l109:
+					position, tokenIndex = position108, tokenIndex108
+					if buffer[position] != rune('N') {
+						goto l106
+					}
+					position++
+				}
+			l108:
+				{
+					position110, tokenIndex110 := position, tokenIndex
+					if buffer[position] != rune('o') {
+						goto l111
+					}
+					position++
+					goto l110
+				l111:
+					position, tokenIndex = position110, tokenIndex110
+					if buffer[position] != rune('O') {
+						goto l106
+					}
+					position++
+				}
+			l110:
+				{
+					position112, tokenIndex112 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l113
+					}
+					position++
+					goto l112
+				l113:
+					position, tokenIndex = position112, tokenIndex112
+					if buffer[position] != rune('T') {
+						goto l106
+					}
+					position++
+				}
+			l112:
 				{
 					position114, tokenIndex114 := position, tokenIndex
+					{
+						switch buffer[position] {
+						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l114
+							}
+							position++
+						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l114
+							}
+							position++
+						case '_':
+							if buffer[position] != rune('_') {
+								goto l114
+							}
+							position++
+						default:
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l114
+							}
+							position++
+						}
+					}
+
+					goto l106
+				l114:
+					position, tokenIndex = position114, tokenIndex114
+				}
+				add(ruleKeyword, position107)
+			}
+			return true
+		l106:
+			position, tokenIndex = position106, tokenIndex106
+			return false
+		},
+		/* 16 Compare <- <((_ <(('>' '=') / ('<' '=') / ('=' '=') / '<' / ((&('=') '=') | (&('<') ('<' '>')) | (&('>') '>') | (&('!') ('!' '='))))> _ Action15) / (_ <((('g' / 'G') ('t' / 'T')) / (('l' / 'L') ('t' / 'T')) / ((&('N' | 'n') (('n' / 'N') ('e' / 'E') ('q' / 'Q'))) | (&('E' | 'e') (('e' / 'E') ('q' / 'Q'))) | (&('L' | 'l') (('l' / 'L') ('t' / 'T') ('e' / 'E'))) | (&('G' | 'g') (('g' / 'G') ('t' / 'T') ('e' / 'E')))))> _ Action16) / (_ <((('l' / 'L') ('i' / 'I') ('k' / 'K') ('e' / 'E')) / (('n' / 'N') ('o' / 'O') ('t' / 'T') __ (('l' / 'L') ('i' / 'I') ('k' / 'K') ('e' / 'E'))))> _ Action17))> */
+		func() bool {
+			position116, tokenIndex116 := position, tokenIndex
+			{
+				position117 := position
+				{
+					position118, tokenIndex118 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l115
+						goto l119
 					}
 					{
-						position116 := position
+						position120 := position
 						{
-							position117, tokenIndex117 := position, tokenIndex
+							position121, tokenIndex121 := position, tokenIndex
 							if buffer[position] != rune('>') {
-								goto l118
+								goto l122
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l118
+								goto l122
 							}
 							position++
-							goto l117
-						l118:
-							position, tokenIndex = position117, tokenIndex117
+							goto l121
+						l122:
+							position, tokenIndex = position121, tokenIndex121
 							if buffer[position] != rune('<') {
-								goto l119
+								goto l123
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l119
+								goto l123
 							}
 							position++
-							goto l117
-						l119:
-							position, tokenIndex = position117, tokenIndex117
+							goto l121
+						l123:
+							position, tokenIndex = position121, tokenIndex121
 							if buffer[position] != rune('=') {
-								goto l120
+								goto l124
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l120
+								goto l124
 							}
 							position++
-							goto l117
-						l120:
-							position, tokenIndex = position117, tokenIndex117
+							goto l121
+						l124:
+							position, tokenIndex = position121, tokenIndex121
 							if buffer[position] != rune('<') {
-								goto l121
+								goto l125
 							}
 							position++
-							goto l117
-						l121:
-							position, tokenIndex = position117, tokenIndex117
+							goto l121
+						l125:
+							position, tokenIndex = position121, tokenIndex121
 							{
 								switch buffer[position] {
 								case '=':
 									if buffer[position] != rune('=') {
-										goto l115
+										goto l119
 									}
 									position++
 								case '<':
 									if buffer[position] != rune('<') {
-										goto l115
+										goto l119
 									}
 									position++
 									if buffer[position] != rune('>') {
-										goto l115
+										goto l119
 									}
 									position++
 								case '>':
 									if buffer[position] != rune('>') {
-										goto l115
+										goto l119
 									}
 									position++
 								default:
 									if buffer[position] != rune('!') {
-										goto l115
+										goto l119
 									}
 									position++
 									if buffer[position] != rune('=') {
-										goto l115
+										goto l119
 									}
 									position++
 								}
 							}
 
 						}
-					l117:
-						add(rulePegText, position116)
+					l121:
+						add(rulePegText, position120)
 					}
 					if !_rules[rule_]() {
-						goto l115
+						goto l119
 					}
 					if !_rules[ruleAction15]() {
-						goto l115
+						goto l119
 					}
-					goto l114
-				l115:
-					position, tokenIndex = position114, tokenIndex114
+					goto l118
+				l119:
+					position, tokenIndex = position118, tokenIndex118
 					if !_rules[rule_]() {
-						goto l123
+						goto l127
 					}
 					{
-						position124 := position
+						position128 := position
 						{
-							position125, tokenIndex125 := position, tokenIndex
+							position129, tokenIndex129 := position, tokenIndex
 							{
-								position127, tokenIndex127 := position, tokenIndex
+								position131, tokenIndex131 := position, tokenIndex
 								if buffer[position] != rune('g') {
-									goto l128
+									goto l132
 								}
 								position++
-								goto l127
-							l128:
-								position, tokenIndex = position127, tokenIndex127
+								goto l131
+							l132:
+								position, tokenIndex = position131, tokenIndex131
 								if buffer[position] != rune('G') {
-									goto l126
+									goto l130
 								}
 								position++
 							}
-						l127:
+						l131:
 							{
-								position129, tokenIndex129 := position, tokenIndex
+								position133, tokenIndex133 := position, tokenIndex
 								if buffer[position] != rune('t') {
-									goto l130
+									goto l134
 								}
 								position++
-								goto l129
-							l130:
-								position, tokenIndex = position129, tokenIndex129
+								goto l133
+							l134:
+								position, tokenIndex = position133, tokenIndex133
 								if buffer[position] != rune('T') {
-									goto l126
+									goto l130
 								}
 								position++
 							}
-						l129:
-							goto l125
-						l126:
-							position, tokenIndex = position125, tokenIndex125
+						l133:
+							goto l129
+						l130:
+							position, tokenIndex = position129, tokenIndex129
 							{
-								position132, tokenIndex132 := position, tokenIndex
+								position136, tokenIndex136 := position, tokenIndex
 								if buffer[position] != rune('l') {
-									goto l133
+									goto l137
 								}
 								position++
-								goto l132
-							l133:
-								position, tokenIndex = position132, tokenIndex132
+								goto l136
+							l137:
+								position, tokenIndex = position136, tokenIndex136
 								if buffer[position] != rune('L') {
-									goto l131
+									goto l135
 								}
 								position++
 							}
-						l132:
+						l136:
 							{
-								position134, tokenIndex134 := position, tokenIndex
+								position138, tokenIndex138 := position, tokenIndex
 								if buffer[position] != rune('t') {
-									goto l135
+									goto l139
 								}
 								position++
-								goto l134
-							l135:
-								position, tokenIndex = position134, tokenIndex134
+								goto l138
+							l139:
+								position, tokenIndex = position138, tokenIndex138
 								if buffer[position] != rune('T') {
-									goto l131
+									goto l135
 								}
 								position++
 							}
-						l134:
-							goto l125
-						l131:
-							position, tokenIndex = position125, tokenIndex125
+						l138:
+							goto l129
+						l135:
+							position, tokenIndex = position129, tokenIndex129
 							{
 								switch buffer[position] {
 								case 'N', 'n':
-									{
-										position137, tokenIndex137 := position, tokenIndex
-										if buffer[position] != rune('n') {
-											goto l138
-										}
-										position++
-										goto l137
-									l138:
-										position, tokenIndex = position137, tokenIndex137
-										if buffer[position] != rune('N') {
-											goto l123
-										}
-										position++
-									}
-								l137:
-									{
-										position139, tokenIndex139 := position, tokenIndex
-										if buffer[position] != rune('e') {
-											goto l140
-										}
-										position++
-										goto l139
-									l140:
-										position, tokenIndex = position139, tokenIndex139
-										if buffer[position] != rune('E') {
-											goto l123
-										}
-										position++
-									}
-								l139:
 									{
 										position141, tokenIndex141 := position, tokenIndex
-										if buffer[position] != rune('q') {
+										if buffer[position] != rune('n') {
 											goto l142
 										}
 										position++
 										goto l141
 									l142:
 										position, tokenIndex = position141, tokenIndex141
-										if buffer[position] != rune('Q') {
-											goto l123
+										if buffer[position] != rune('N') {
+											goto l127
 										}
 										position++
 									}
 								l141:
-									break
-								case 'E', 'e':
 									{
 										position143, tokenIndex143 := position, tokenIndex
 										if buffer[position] != rune('e') {
@@ -1709,7 +1736,7 @@ func (p *MiniQueryPeg) Init(options ...func(*MiniQueryPeg) error) error {
 									l144:
 										position, tokenIndex = position143, tokenIndex143
 										if buffer[position] != rune('E') {
-											goto l123
+											goto l127
 										}
 										position++
 									}
@@ -1724,1443 +1751,1675 @@ func (p *MiniQueryPeg) Init(options ...func(*MiniQueryPeg) error) error {
 									l146:
 										position, tokenIndex = position145, tokenIndex145
 										if buffer[position] != rune('Q') {
-											goto l123
+											goto l127
 										}
 										position++
 									}
 								l145:
 									break
-								case 'L', 'l':
+								case 'E', 'e':
 									{
 										position147, tokenIndex147 := position, tokenIndex
-										if buffer[position] != rune('l') {
+										if buffer[position] != rune('e') {
 											goto l148
 										}
 										position++
 										goto l147
 									l148:
 										position, tokenIndex = position147, tokenIndex147
-										if buffer[position] != rune('L') {
-											goto l123
+										if buffer[position] != rune('E') {
+											goto l127
 										}
 										position++
 									}
 								l147:
 									{
 										position149, tokenIndex149 := position, tokenIndex
-										if buffer[position] != rune('t') {
+										if buffer[position] != rune('q') {
 											goto l150
 										}
 										position++
 										goto l149
 									l150:
 										position, tokenIndex = position149, tokenIndex149
-										if buffer[position] != rune('T') {
-											goto l123
+										if buffer[position] != rune('Q') {
+											goto l127
 										}
 										position++
 									}
 								l149:
+									break
+								case 'L', 'l':
 									{
 										position151, tokenIndex151 := position, tokenIndex
-										if buffer[position] != rune('e') {
+										if buffer[position] != rune('l') {
 											goto l152
 										}
 										position++
 										goto l151
 									l152:
 										position, tokenIndex = position151, tokenIndex151
-										if buffer[position] != rune('E') {
-											goto l123
+										if buffer[position] != rune('L') {
+											goto l127
 										}
 										position++
 									}
 								l151:
-									break
-								default:
 									{
 										position153, tokenIndex153 := position, tokenIndex
-										if buffer[position] != rune('g') {
+										if buffer[position] != rune('t') {
 											goto l154
 										}
 										position++
 										goto l153
 									l154:
 										position, tokenIndex = position153, tokenIndex153
-										if buffer[position] != rune('G') {
-											goto l123
+										if buffer[position] != rune('T') {
+											goto l127
 										}
 										position++
 									}
 								l153:
 									{
 										position155, tokenIndex155 := position, tokenIndex
-										if buffer[position] != rune('t') {
+										if buffer[position] != rune('e') {
 											goto l156
 										}
 										position++
 										goto l155
 									l156:
 										position, tokenIndex = position155, tokenIndex155
-										if buffer[position] != rune('T') {
-											goto l123
+										if buffer[position] != rune('E') {
+											goto l127
 										}
 										position++
 									}
 								l155:
+									break
+								default:
 									{
 										position157, tokenIndex157 := position, tokenIndex
-										if buffer[position] != rune('e') {
+										if buffer[position] != rune('g') {
 											goto l158
 										}
 										position++
 										goto l157
 									l158:
 										position, tokenIndex = position157, tokenIndex157
-										if buffer[position] != rune('E') {
-											goto l123
+										if buffer[position] != rune('G') {
+											goto l127
 										}
 										position++
 									}
 								l157:
+									{
+										position159, tokenIndex159 := position, tokenIndex
+										if buffer[position] != rune('t') {
+											goto l160
+										}
+										position++
+										goto l159
+									l160:
+										position, tokenIndex = position159, tokenIndex159
+										if buffer[position] != rune('T') {
+											goto l127
+										}
+										position++
+									}
+								l159:
+									{
+										position161, tokenIndex161 := position, tokenIndex
+										if buffer[position] != rune('e') {
+											goto l162
+										}
+										position++
+										goto l161
+									l162:
+										position, tokenIndex = position161, tokenIndex161
+										if buffer[position] != rune('E') {
+											goto l127
+										}
+										position++
+									}
+								l161:
 									break
 								}
 							}
 
 						}
-					l125:
-						add(rulePegText, position124)
+					l129:
+						add(rulePegText, position128)
 					}
 					if !_rules[rule_]() {
-						goto l123
+						goto l127
 					}
 					if !_rules[ruleAction16]() {
-						goto l123
+						goto l127
 					}
-					goto l114
-				l123:
-					position, tokenIndex = position114, tokenIndex114
+					goto l118
+				l127:
+					position, tokenIndex = position118, tokenIndex118
 					if !_rules[rule_]() {
-						goto l112
+						goto l116
 					}
 					{
-						position159 := position
+						position163 := position
 						{
-							position160, tokenIndex160 := position, tokenIndex
-							{
-								position162, tokenIndex162 := position, tokenIndex
-								if buffer[position] != rune('l') {
-									goto l163
-								}
-								position++
-								goto l162
-							l163:
-								position, tokenIndex = position162, tokenIndex162
-								if buffer[position] != rune('L') {
-									goto l161
-								}
-								position++
-							}
-						l162:
-							{
-								position164, tokenIndex164 := position, tokenIndex
-								if buffer[position] != rune('i') {
-									goto l165
-								}
-								position++
-								goto l164
-							l165:
-								position, tokenIndex = position164, tokenIndex164
-								if buffer[position] != rune('I') {
-									goto l161
-								}
-								position++
-							}
-						l164:
+							position164, tokenIndex164 := position, tokenIndex
 							{
 								position166, tokenIndex166 := position, tokenIndex
-								if buffer[position] != rune('k') {
+								if buffer[position] != rune('l') {
 									goto l167
 								}
 								position++
 								goto l166
 							l167:
 								position, tokenIndex = position166, tokenIndex166
-								if buffer[position] != rune('K') {
-									goto l161
+								if buffer[position] != rune('L') {
+									goto l165
 								}
 								position++
 							}
 						l166:
 							{
 								position168, tokenIndex168 := position, tokenIndex
-								if buffer[position] != rune('e') {
+								if buffer[position] != rune('i') {
 									goto l169
 								}
 								position++
 								goto l168
 							l169:
 								position, tokenIndex = position168, tokenIndex168
-								if buffer[position] != rune('E') {
-									goto l161
+								if buffer[position] != rune('I') {
+									goto l165
 								}
 								position++
 							}
 						l168:
-							goto l160
-						l161:
-							position, tokenIndex = position160, tokenIndex160
 							{
 								position170, tokenIndex170 := position, tokenIndex
-								if buffer[position] != rune('n') {
+								if buffer[position] != rune('k') {
 									goto l171
 								}
 								position++
 								goto l170
 							l171:
 								position, tokenIndex = position170, tokenIndex170
-								if buffer[position] != rune('N') {
-									goto l112
+								if buffer[position] != rune('K') {
+									goto l165
 								}
 								position++
 							}
 						l170:
 							{
 								position172, tokenIndex172 := position, tokenIndex
-								if buffer[position] != rune('o') {
+								if buffer[position] != rune('e') {
 									goto l173
 								}
 								position++
 								goto l172
 							l173:
 								position, tokenIndex = position172, tokenIndex172
-								if buffer[position] != rune('O') {
-									goto l112
+								if buffer[position] != rune('E') {
+									goto l165
 								}
 								position++
 							}
 						l172:
+							goto l164
+						l165:
+							position, tokenIndex = position164, tokenIndex164
 							{
 								position174, tokenIndex174 := position, tokenIndex
-								if buffer[position] != rune('t') {
+								if buffer[position] != rune('n') {
 									goto l175
 								}
 								position++
 								goto l174
 							l175:
 								position, tokenIndex = position174, tokenIndex174
-								if buffer[position] != rune('T') {
-									goto l112
+								if buffer[position] != rune('N') {
+									goto l116
 								}
 								position++
 							}
 						l174:
-							if !_rules[rule__]() {
-								goto l112
-							}
 							{
 								position176, tokenIndex176 := position, tokenIndex
-								if buffer[position] != rune('l') {
+								if buffer[position] != rune('o') {
 									goto l177
 								}
 								position++
 								goto l176
 							l177:
 								position, tokenIndex = position176, tokenIndex176
-								if buffer[position] != rune('L') {
-									goto l112
+								if buffer[position] != rune('O') {
+									goto l116
 								}
 								position++
 							}
 						l176:
 							{
 								position178, tokenIndex178 := position, tokenIndex
-								if buffer[position] != rune('i') {
+								if buffer[position] != rune('t') {
 									goto l179
 								}
 								position++
 								goto l178
 							l179:
 								position, tokenIndex = position178, tokenIndex178
-								if buffer[position] != rune('I') {
-									goto l112
+								if buffer[position] != rune('T') {
+									goto l116
 								}
 								position++
 							}
 						l178:
+							if !_rules[rule__]() {
+								goto l116
+							}
 							{
 								position180, tokenIndex180 := position, tokenIndex
-								if buffer[position] != rune('k') {
+								if buffer[position] != rune('l') {
 									goto l181
 								}
 								position++
 								goto l180
 							l181:
 								position, tokenIndex = position180, tokenIndex180
-								if buffer[position] != rune('K') {
-									goto l112
+								if buffer[position] != rune('L') {
+									goto l116
 								}
 								position++
 							}
 						l180:
 							{
 								position182, tokenIndex182 := position, tokenIndex
-								if buffer[position] != rune('e') {
+								if buffer[position] != rune('i') {
 									goto l183
 								}
 								position++
 								goto l182
 							l183:
 								position, tokenIndex = position182, tokenIndex182
-								if buffer[position] != rune('E') {
-									goto l112
+								if buffer[position] != rune('I') {
+									goto l116
 								}
 								position++
 							}
 						l182:
+							{
+								position184, tokenIndex184 := position, tokenIndex
+								if buffer[position] != rune('k') {
+									goto l185
+								}
+								position++
+								goto l184
+							l185:
+								position, tokenIndex = position184, tokenIndex184
+								if buffer[position] != rune('K') {
+									goto l116
+								}
+								position++
+							}
+						l184:
+							{
+								position186, tokenIndex186 := position, tokenIndex
+								if buffer[position] != rune('e') {
+									goto l187
+								}
+								position++
+								goto l186
+							l187:
+								position, tokenIndex = position186, tokenIndex186
+								if buffer[position] != rune('E') {
+									goto l116
+								}
+								position++
+							}
+						l186:
 						}
-					l160:
-						add(rulePegText, position159)
+					l164:
+						add(rulePegText, position163)
 					}
 					if !_rules[rule_]() {
-						goto l112
+						goto l116
 					}
 					if !_rules[ruleAction17]() {
-						goto l112
+						goto l116
 					}
 				}
-			l114:
-				add(ruleCompare, position113)
+			l118:
+				add(ruleCompare, position117)
 			}
 			return true
-		l112:
-			position, tokenIndex = position112, tokenIndex112
+		l116:
+			position, tokenIndex = position116, tokenIndex116
 			return false
 		},
-		/* 16 Logic <- <((_ <((('a' / 'A') ('n' / 'N') ('d' / 'D')) / (('o' / 'O') ('r' / 'R')))> _ Action18) / (_ <(('&' '&') / ('|' '|'))> _ Action19))> */
+		/* 17 Logic <- <((_ <((('a' / 'A') ('n' / 'N') ('d' / 'D')) / (('o' / 'O') ('r' / 'R')))> _ Action18) / (_ <(('&' '&') / ('|' '|'))> _ Action19))> */
 		func() bool {
-			position184, tokenIndex184 := position, tokenIndex
+			position188, tokenIndex188 := position, tokenIndex
 			{
-				position185 := position
+				position189 := position
 				{
-					position186, tokenIndex186 := position, tokenIndex
+					position190, tokenIndex190 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l187
+						goto l191
 					}
 					{
-						position188 := position
+						position192 := position
 						{
-							position189, tokenIndex189 := position, tokenIndex
+							position193, tokenIndex193 := position, tokenIndex
 							{
-								position191, tokenIndex191 := position, tokenIndex
+								position195, tokenIndex195 := position, tokenIndex
 								if buffer[position] != rune('a') {
-									goto l192
+									goto l196
 								}
 								position++
-								goto l191
-							l192:
-								position, tokenIndex = position191, tokenIndex191
+								goto l195
+							l196:
+								position, tokenIndex = position195, tokenIndex195
 								if buffer[position] != rune('A') {
-									goto l190
+									goto l194
 								}
 								position++
 							}
-						l191:
+						l195:
 							{
-								position193, tokenIndex193 := position, tokenIndex
+								position197, tokenIndex197 := position, tokenIndex
 								if buffer[position] != rune('n') {
-									goto l194
+									goto l198
 								}
 								position++
-								goto l193
-							l194:
-								position, tokenIndex = position193, tokenIndex193
+								goto l197
+							l198:
+								position, tokenIndex = position197, tokenIndex197
 								if buffer[position] != rune('N') {
-									goto l190
+									goto l194
 								}
 								position++
 							}
-						l193:
+						l197:
 							{
-								position195, tokenIndex195 := position, tokenIndex
+								position199, tokenIndex199 := position, tokenIndex
 								if buffer[position] != rune('d') {
-									goto l196
+									goto l200
 								}
 								position++
-								goto l195
-							l196:
-								position, tokenIndex = position195, tokenIndex195
+								goto l199
+							l200:
+								position, tokenIndex = position199, tokenIndex199
 								if buffer[position] != rune('D') {
-									goto l190
+									goto l194
 								}
 								position++
 							}
-						l195:
-							goto l189
-						l190:
-							position, tokenIndex = position189, tokenIndex189
+						l199:
+							goto l193
+						l194:
+							position, tokenIndex = position193, tokenIndex193
 							{
-								position197, tokenIndex197 := position, tokenIndex
+								position201, tokenIndex201 := position, tokenIndex
 								if buffer[position] != rune('o') {
-									goto l198
+									goto l202
 								}
 								position++
-								goto l197
-							l198:
-								position, tokenIndex = position197, tokenIndex197
+								goto l201
+							l202:
+								position, tokenIndex = position201, tokenIndex201
 								if buffer[position] != rune('O') {
-									goto l187
+									goto l191
 								}
 								position++
 							}
-						l197:
+						l201:
 							{
-								position199, tokenIndex199 := position, tokenIndex
+								position203, tokenIndex203 := position, tokenIndex
 								if buffer[position] != rune('r') {
-									goto l200
+									goto l204
 								}
 								position++
-								goto l199
-							l200:
-								position, tokenIndex = position199, tokenIndex199
+								goto l203
+							l204:
+								position, tokenIndex = position203, tokenIndex203
 								if buffer[position] != rune('R') {
-									goto l187
+									goto l191
 								}
 								position++
 							}
-						l199:
+						l203:
 						}
-					l189:
-						add(rulePegText, position188)
+					l193:
+						add(rulePegText, position192)
 					}
 					if !_rules[rule_]() {
-						goto l187
+						goto l191
 					}
 					if !_rules[ruleAction18]() {
-						goto l187
+						goto l191
 					}
-					goto l186
-				l187:
-					position, tokenIndex = position186, tokenIndex186
+					goto l190
+				l191:
+					position, tokenIndex = position190, tokenIndex190
 					if !_rules[rule_]() {
-						goto l184
+						goto l188
 					}
 					{
-						position201 := position
+						position205 := position
 						{
-							position202, tokenIndex202 := position, tokenIndex
+							position206, tokenIndex206 := position, tokenIndex
 							if buffer[position] != rune('&') {
-								goto l203
+								goto l207
 							}
 							position++
 							if buffer[position] != rune('&') {
-								goto l203
+								goto l207
 							}
 							position++
-							goto l202
-						l203:
-							position, tokenIndex = position202, tokenIndex202
+							goto l206
+						l207:
+							position, tokenIndex = position206, tokenIndex206
 							if buffer[position] != rune('|') {
-								goto l184
+								goto l188
 							}
 							position++
 							if buffer[position] != rune('|') {
-								goto l184
+								goto l188
 							}
 							position++
 						}
-					l202:
-						add(rulePegText, position201)
+					l206:
+						add(rulePegText, position205)
 					}
 					if !_rules[rule_]() {
-						goto l184
+						goto l188
 					}
 					if !_rules[ruleAction19]() {
-						goto l184
+						goto l188
 					}
 				}
-			l186:
-				add(ruleLogic, position185)
+			l190:
+				add(ruleLogic, position189)
 			}
 			return true
-		l184:
-			position, tokenIndex = position184, tokenIndex184
+		l188:
+			position, tokenIndex = position188, tokenIndex188
 			return false
 		},
-		/* 17 Match <- <(__ <(('i' 's' 'n' 'u' 'l' 'l') / ('n' 'o' 't' 'n' 'u' 'l' 'l') / ('i' 's' __ ((&('n') ('n' 'u' 'l' 'l')) | (&('f') ('f' 'a' 'l' 's' 'e')) | (&('t') ('t' 'r' 'u' 'e')))) / ('i' 's' __ ('n' 'o' 't') __ ((&('n') ('n' 'u' 'l' 'l')) | (&('f') ('f' 'a' 'l' 's' 'e')) | (&('t') ('t' 'r' 'u' 'e')))))> _ Action20)> */
+		/* 18 Match <- <(__ <(('i' 's' 'n' 'u' 'l' 'l') / ('n' 'o' 't' 'n' 'u' 'l' 'l') / ('i' 's' __ ((&('n') ('n' 'u' 'l' 'l')) | (&('f') ('f' 'a' 'l' 's' 'e')) | (&('t') ('t' 'r' 'u' 'e')))) / ('i' 's' __ ('n' 'o' 't') __ ((&('n') ('n' 'u' 'l' 'l')) | (&('f') ('f' 'a' 'l' 's' 'e')) | (&('t') ('t' 'r' 'u' 'e')))))> _ Action20)> */
 		func() bool {
-			position204, tokenIndex204 := position, tokenIndex
+			position208, tokenIndex208 := position, tokenIndex
 			{
-				position205 := position
+				position209 := position
 				if !_rules[rule__]() {
-					goto l204
+					goto l208
 				}
 				{
-					position206 := position
+					position210 := position
 					{
-						position207, tokenIndex207 := position, tokenIndex
+						position211, tokenIndex211 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l208
+							goto l212
 						}
 						position++
 						if buffer[position] != rune('s') {
-							goto l208
+							goto l212
 						}
 						position++
 						if buffer[position] != rune('n') {
-							goto l208
+							goto l212
 						}
 						position++
 						if buffer[position] != rune('u') {
-							goto l208
+							goto l212
 						}
 						position++
 						if buffer[position] != rune('l') {
-							goto l208
+							goto l212
 						}
 						position++
 						if buffer[position] != rune('l') {
-							goto l208
+							goto l212
 						}
 						position++
-						goto l207
-					l208:
-						position, tokenIndex = position207, tokenIndex207
+						goto l211
+					l212:
+						position, tokenIndex = position211, tokenIndex211
 						if buffer[position] != rune('n') {
-							goto l209
+							goto l213
 						}
 						position++
 						if buffer[position] != rune('o') {
-							goto l209
+							goto l213
 						}
 						position++
 						if buffer[position] != rune('t') {
-							goto l209
+							goto l213
 						}
 						position++
 						if buffer[position] != rune('n') {
-							goto l209
+							goto l213
 						}
 						position++
 						if buffer[position] != rune('u') {
-							goto l209
+							goto l213
 						}
 						position++
 						if buffer[position] != rune('l') {
-							goto l209
+							goto l213
 						}
 						position++
 						if buffer[position] != rune('l') {
-							goto l209
+							goto l213
 						}
 						position++
-						goto l207
-					l209:
-						position, tokenIndex = position207, tokenIndex207
+						goto l211
+					l213:
+						position, tokenIndex = position211, tokenIndex211
 						if buffer[position] != rune('i') {
-							goto l210
+							goto l214
 						}
 						position++
 						if buffer[position] != rune('s') {
-							goto l210
+							goto l214
 						}
 						position++
 						if !_rules[rule__]() {
-							goto l210
+							goto l214
 						}
 						{
 							switch buffer[position] {
 							case 'n':
 								if buffer[position] != rune('n') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('u') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l210
+									goto l214
 								}
 								position++
 							case 'f':
 								if buffer[position] != rune('f') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('a') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('s') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('e') {
-									goto l210
+									goto l214
 								}
 								position++
 							default:
 								if buffer[position] != rune('t') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('r') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('u') {
-									goto l210
+									goto l214
 								}
 								position++
 								if buffer[position] != rune('e') {
-									goto l210
+									goto l214
 								}
 								position++
 							}
 						}
 
-						goto l207
-					l210:
-						position, tokenIndex = position207, tokenIndex207
+						goto l211
+					l214:
+						position, tokenIndex = position211, tokenIndex211
 						if buffer[position] != rune('i') {
-							goto l204
+							goto l208
 						}
 						position++
 						if buffer[position] != rune('s') {
-							goto l204
+							goto l208
 						}
 						position++
 						if !_rules[rule__]() {
-							goto l204
+							goto l208
 						}
 						if buffer[position] != rune('n') {
-							goto l204
+							goto l208
 						}
 						position++
 						if buffer[position] != rune('o') {
-							goto l204
+							goto l208
 						}
 						position++
 						if buffer[position] != rune('t') {
-							goto l204
+							goto l208
 						}
 						position++
 						if !_rules[rule__]() {
-							goto l204
+							goto l208
 						}
 						{
 							switch buffer[position] {
 							case 'n':
 								if buffer[position] != rune('n') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('u') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l204
+									goto l208
 								}
 								position++
 							case 'f':
 								if buffer[position] != rune('f') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('a') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('s') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('e') {
-									goto l204
+									goto l208
 								}
 								position++
 							default:
 								if buffer[position] != rune('t') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('r') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('u') {
-									goto l204
+									goto l208
 								}
 								position++
 								if buffer[position] != rune('e') {
-									goto l204
+									goto l208
 								}
 								position++
 							}
 						}
 
 					}
-				l207:
-					add(rulePegText, position206)
+				l211:
+					add(rulePegText, position210)
 				}
 				if !_rules[rule_]() {
-					goto l204
+					goto l208
 				}
 				if !_rules[ruleAction20]() {
-					goto l204
+					goto l208
 				}
-				add(ruleMatch, position205)
+				add(ruleMatch, position209)
 			}
 			return true
-		l204:
-			position, tokenIndex = position204, tokenIndex204
+		l208:
+			position, tokenIndex = position208, tokenIndex208
 			return false
 		},
-		/* 18 Value <- <(Literal / Array)> */
+		/* 19 Value <- <(Literal / Array)> */
 		func() bool {
-			position213, tokenIndex213 := position, tokenIndex
+			position217, tokenIndex217 := position, tokenIndex
 			{
-				position214 := position
+				position218 := position
 				{
-					position215, tokenIndex215 := position, tokenIndex
+					position219, tokenIndex219 := position, tokenIndex
 					if !_rules[ruleLiteral]() {
-						goto l216
+						goto l220
 					}
-					goto l215
-				l216:
-					position, tokenIndex = position215, tokenIndex215
+					goto l219
+				l220:
+					position, tokenIndex = position219, tokenIndex219
 					if !_rules[ruleArray]() {
-						goto l213
+						goto l217
 					}
 				}
-			l215:
-				add(ruleValue, position214)
+			l219:
+				add(ruleValue, position218)
 			}
 			return true
-		l213:
-			position, tokenIndex = position213, tokenIndex213
+		l217:
+			position, tokenIndex = position217, tokenIndex217
 			return false
 		},
-		/* 19 Array <- <(('[' Action21 _ (Literal (_ ',' _ Literal)* _ ','?)? _ ']' Action22) / ('(' Action23 _ (Literal (_ ',' _ Literal)* _ ','?)? _ ')' Action24))> */
+		/* 20 Array <- <(('[' Action21 _ (Literal (_ ',' _ Literal)* _ ','?)? _ ']' Action22) / ('(' Action23 _ (Literal (_ ',' _ Literal)* _ ','?)? _ ')' Action24))> */
 		func() bool {
-			position217, tokenIndex217 := position, tokenIndex
+			position221, tokenIndex221 := position, tokenIndex
 			{
-				position218 := position
+				position222 := position
 				{
-					position219, tokenIndex219 := position, tokenIndex
+					position223, tokenIndex223 := position, tokenIndex
 					if buffer[position] != rune('[') {
-						goto l220
+						goto l224
 					}
 					position++
 					if !_rules[ruleAction21]() {
-						goto l220
+						goto l224
 					}
 					if !_rules[rule_]() {
-						goto l220
+						goto l224
 					}
 					{
-						position221, tokenIndex221 := position, tokenIndex
+						position225, tokenIndex225 := position, tokenIndex
 						if !_rules[ruleLiteral]() {
-							goto l221
+							goto l225
 						}
-					l223:
+					l227:
 						{
-							position224, tokenIndex224 := position, tokenIndex
+							position228, tokenIndex228 := position, tokenIndex
 							if !_rules[rule_]() {
-								goto l224
+								goto l228
 							}
 							if buffer[position] != rune(',') {
-								goto l224
+								goto l228
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l224
+								goto l228
 							}
 							if !_rules[ruleLiteral]() {
-								goto l224
+								goto l228
 							}
-							goto l223
-						l224:
-							position, tokenIndex = position224, tokenIndex224
+							goto l227
+						l228:
+							position, tokenIndex = position228, tokenIndex228
 						}
 						if !_rules[rule_]() {
-							goto l221
+							goto l225
 						}
 						{
-							position225, tokenIndex225 := position, tokenIndex
+							position229, tokenIndex229 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l225
+								goto l229
 							}
 							position++
-							goto l226
-						l225:
-							position, tokenIndex = position225, tokenIndex225
+							goto l230
+						l229:
+							position, tokenIndex = position229, tokenIndex229
 						}
-					l226:
-						goto l222
-					l221:
-						position, tokenIndex = position221, tokenIndex221
+					l230:
+						goto l226
+					l225:
+						position, tokenIndex = position225, tokenIndex225
 					}
-				l222:
+				l226:
 					if !_rules[rule_]() {
-						goto l220
+						goto l224
 					}
 					if buffer[position] != rune(']') {
-						goto l220
+						goto l224
 					}
 					position++
 					if !_rules[ruleAction22]() {
-						goto l220
+						goto l224
 					}
-					goto l219
-				l220:
-					position, tokenIndex = position219, tokenIndex219
+					goto l223
+				l224:
+					position, tokenIndex = position223, tokenIndex223
 					if buffer[position] != rune('(') {
-						goto l217
+						goto l221
 					}
 					position++
 					if !_rules[ruleAction23]() {
-						goto l217
+						goto l221
 					}
 					if !_rules[rule_]() {
-						goto l217
+						goto l221
 					}
 					{
-						position227, tokenIndex227 := position, tokenIndex
+						position231, tokenIndex231 := position, tokenIndex
 						if !_rules[ruleLiteral]() {
-							goto l227
+							goto l231
 						}
-					l229:
+					l233:
 						{
-							position230, tokenIndex230 := position, tokenIndex
+							position234, tokenIndex234 := position, tokenIndex
 							if !_rules[rule_]() {
-								goto l230
+								goto l234
 							}
 							if buffer[position] != rune(',') {
-								goto l230
+								goto l234
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l230
+								goto l234
 							}
 							if !_rules[ruleLiteral]() {
-								goto l230
+								goto l234
 							}
-							goto l229
-						l230:
-							position, tokenIndex = position230, tokenIndex230
+							goto l233
+						l234:
+							position, tokenIndex = position234, tokenIndex234
 						}
 						if !_rules[rule_]() {
-							goto l227
+							goto l231
 						}
 						{
-							position231, tokenIndex231 := position, tokenIndex
+							position235, tokenIndex235 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l231
+								goto l235
 							}
 							position++
-							goto l232
-						l231:
-							position, tokenIndex = position231, tokenIndex231
+							goto l236
+						l235:
+							position, tokenIndex = position235, tokenIndex235
 						}
-					l232:
-						goto l228
-					l227:
-						position, tokenIndex = position227, tokenIndex227
+					l236:
+						goto l232
+					l231:
+						position, tokenIndex = position231, tokenIndex231
 					}
-				l228:
+				l232:
 					if !_rules[rule_]() {
-						goto l217
+						goto l221
 					}
 					if buffer[position] != rune(')') {
-						goto l217
+						goto l221
 					}
 					position++
 					if !_rules[ruleAction24]() {
-						goto l217
+						goto l221
 					}
 				}
-			l219:
-				add(ruleArray, position218)
+			l223:
+				add(ruleArray, position222)
 			}
 			return true
-		l217:
-			position, tokenIndex = position217, tokenIndex217
+		l221:
+			position, tokenIndex = position221, tokenIndex221
 			return false
 		},
-		/* 20 Literal <- <((&('N' | 'n') Null) | (&('F' | 'T' | 'f' | 't') Boolean) | (&('"' | '\'') String) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') Integer))> */
+		/* 21 Literal <- <(Float / ((&(':') Parameter) | (&('N' | 'n') Null) | (&('F' | 'T' | 'f' | 't') Boolean) | (&('"' | '\'') String) | (&('-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') Integer)))> */
 		func() bool {
-			position233, tokenIndex233 := position, tokenIndex
+			position237, tokenIndex237 := position, tokenIndex
 			{
-				position234 := position
+				position238 := position
 				{
-					switch buffer[position] {
-					case 'N', 'n':
-						if !_rules[ruleNull]() {
-							goto l233
+					position239, tokenIndex239 := position, tokenIndex
+					if !_rules[ruleFloat]() {
+						goto l240
+					}
+					goto l239
+				l240:
+					position, tokenIndex = position239, tokenIndex239
+					{
+						switch buffer[position] {
+						case ':':
+							if !_rules[ruleParameter]() {
+								goto l237
+							}
+						case 'N', 'n':
+							if !_rules[ruleNull]() {
+								goto l237
+							}
+						case 'F', 'T', 'f', 't':
+							if !_rules[ruleBoolean]() {
+								goto l237
+							}
+						case '"', '\'':
+							if !_rules[ruleString]() {
+								goto l237
+							}
+						default:
+							if !_rules[ruleInteger]() {
+								goto l237
+							}
 						}
-					case 'F', 'T', 'f', 't':
-						if !_rules[ruleBoolean]() {
-							goto l233
+					}
+
+				}
+			l239:
+				add(ruleLiteral, position238)
+			}
+			return true
+		l237:
+			position, tokenIndex = position237, tokenIndex237
+			return false
+		},
+		/* 22 Float <- <(<('-'? ('0' / ([1-9] [0-9]*)) '.' [0-9]+)> Action25)> */
+		func() bool {
+			position242, tokenIndex242 := position, tokenIndex
+			{
+				position243 := position
+				{
+					position244 := position
+					{
+						position245, tokenIndex245 := position, tokenIndex
+						if buffer[position] != rune('-') {
+							goto l245
 						}
-					case '"', '\'':
-						if !_rules[ruleString]() {
-							goto l233
+						position++
+						goto l246
+					l245:
+						position, tokenIndex = position245, tokenIndex245
+					}
+				l246:
+					{
+						position247, tokenIndex247 := position, tokenIndex
+						if buffer[position] != rune('0') {
+							goto l248
 						}
-					default:
-						if !_rules[ruleInteger]() {
-							goto l233
+						position++
+						goto l247
+					l248:
+						position, tokenIndex = position247, tokenIndex247
+						if c := buffer[position]; c < rune('1') || c > rune('9') {
+							goto l242
 						}
+						position++
+					l249:
+						{
+							position250, tokenIndex250 := position, tokenIndex
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l250
+							}
+							position++
+							goto l249
+						l250:
+							position, tokenIndex = position250, tokenIndex250
+						}
+					}
+				l247:
+					if buffer[position] != rune('.') {
+						goto l242
 					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l242
+					}
+					position++
+				l251:
+					{
+						position252, tokenIndex252 := position, tokenIndex
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l252
+						}
+						position++
+						goto l251
+					l252:
+						position, tokenIndex = position252, tokenIndex252
+					}
+					add(rulePegText, position244)
 				}
-
-				add(ruleLiteral, position234)
+				if !_rules[ruleAction25]() {
+					goto l242
+				}
+				add(ruleFloat, position243)
 			}
 			return true
-		l233:
-			position, tokenIndex = position233, tokenIndex233
+		l242:
+			position, tokenIndex = position242, tokenIndex242
 			return false
 		},
-		/* 21 Integer <- <(<('0' / ([1-9] [0-9]*))> Action25)> */
+		/* 23 Integer <- <(<('-'? ('0' / ([1-9] [0-9]*)))> Action26)> */
 		func() bool {
-			position236, tokenIndex236 := position, tokenIndex
+			position253, tokenIndex253 := position, tokenIndex
 			{
-				position237 := position
+				position254 := position
 				{
-					position238 := position
+					position255 := position
+					{
+						position256, tokenIndex256 := position, tokenIndex
+						if buffer[position] != rune('-') {
+							goto l256
+						}
+						position++
+						goto l257
+					l256:
+						position, tokenIndex = position256, tokenIndex256
+					}
+				l257:
 					{
-						position239, tokenIndex239 := position, tokenIndex
+						position258, tokenIndex258 := position, tokenIndex
 						if buffer[position] != rune('0') {
-							goto l240
+							goto l259
 						}
 						position++
-						goto l239
-					l240:
-						position, tokenIndex = position239, tokenIndex239
+						goto l258
+					l259:
+						position, tokenIndex = position258, tokenIndex258
 						if c := buffer[position]; c < rune('1') || c > rune('9') {
-							goto l236
+							goto l253
 						}
 						position++
-					l241:
+					l260:
 						{
-							position242, tokenIndex242 := position, tokenIndex
+							position261, tokenIndex261 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l242
+								goto l261
 							}
 							position++
-							goto l241
-						l242:
-							position, tokenIndex = position242, tokenIndex242
+							goto l260
+						l261:
+							position, tokenIndex = position261, tokenIndex261
 						}
 					}
-				l239:
-					add(rulePegText, position238)
+				l258:
+					add(rulePegText, position255)
 				}
-				if !_rules[ruleAction25]() {
-					goto l236
+				if !_rules[ruleAction26]() {
+					goto l253
 				}
-				add(ruleInteger, position237)
+				add(ruleInteger, position254)
 			}
 			return true
-		l236:
-			position, tokenIndex = position236, tokenIndex236
+		l253:
+			position, tokenIndex = position253, tokenIndex253
 			return false
 		},
-		/* 22 Boolean <- <(<((&('F') ('F' 'A' 'L' 'S' 'E')) | (&('T') ('T' 'R' 'U' 'E')) | (&('f') ('f' 'a' 'l' 's' 'e')) | (&('t') ('t' 'r' 'u' 'e')))> Action26)> */
+		/* 24 Boolean <- <(<((&('F') ('F' 'A' 'L' 'S' 'E')) | (&('T') ('T' 'R' 'U' 'E')) | (&('f') ('f' 'a' 'l' 's' 'e')) | (&('t') ('t' 'r' 'u' 'e')))> Action27)> */
 		func() bool {
-			position243, tokenIndex243 := position, tokenIndex
+			position262, tokenIndex262 := position, tokenIndex
 			{
-				position244 := position
+				position263 := position
 				{
-					position245 := position
+					position264 := position
 					{
 						switch buffer[position] {
 						case 'F':
 							if buffer[position] != rune('F') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('A') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('L') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('S') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('E') {
-								goto l243
+								goto l262
 							}
 							position++
 						case 'T':
 							if buffer[position] != rune('T') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('R') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('U') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('E') {
-								goto l243
+								goto l262
 							}
 							position++
 						case 'f':
 							if buffer[position] != rune('f') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('a') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('l') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('s') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('e') {
-								goto l243
+								goto l262
 							}
 							position++
 						default:
 							if buffer[position] != rune('t') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('r') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('u') {
-								goto l243
+								goto l262
 							}
 							position++
 							if buffer[position] != rune('e') {
-								goto l243
+								goto l262
 							}
 							position++
 						}
 					}
 
-					add(rulePegText, position245)
+					add(rulePegText, position264)
 				}
-				if !_rules[ruleAction26]() {
-					goto l243
+				if !_rules[ruleAction27]() {
+					goto l262
 				}
-				add(ruleBoolean, position244)
+				add(ruleBoolean, position263)
 			}
 			return true
-		l243:
-			position, tokenIndex = position243, tokenIndex243
+		l262:
+			position, tokenIndex = position262, tokenIndex262
 			return false
 		},
-		/* 23 Null <- <(<(('n' 'u' 'l' 'l') / ('N' 'U' 'L' 'L'))> Action27)> */
+		/* 25 Null <- <(<(('n' 'u' 'l' 'l') / ('N' 'U' 'L' 'L'))> Action28)> */
 		func() bool {
-			position247, tokenIndex247 := position, tokenIndex
+			position266, tokenIndex266 := position, tokenIndex
 			{
-				position248 := position
+				position267 := position
 				{
-					position249 := position
+					position268 := position
 					{
-						position250, tokenIndex250 := position, tokenIndex
+						position269, tokenIndex269 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l251
+							goto l270
 						}
 						position++
 						if buffer[position] != rune('u') {
-							goto l251
+							goto l270
 						}
 						position++
 						if buffer[position] != rune('l') {
-							goto l251
+							goto l270
 						}
 						position++
 						if buffer[position] != rune('l') {
-							goto l251
+							goto l270
 						}
 						position++
-						goto l250
-					l251:
-						position, tokenIndex = position250, tokenIndex250
+						goto l269
+					l270:
+						position, tokenIndex = position269, tokenIndex269
 						if buffer[position] != rune('N') {
-							goto l247
+							goto l266
 						}
 						position++
 						if buffer[position] != rune('U') {
-							goto l247
+							goto l266
 						}
 						position++
 						if buffer[position] != rune('L') {
-							goto l247
+							goto l266
 						}
 						position++
 						if buffer[position] != rune('L') {
-							goto l247
+							goto l266
 						}
 						position++
 					}
-				l250:
-					add(rulePegText, position249)
+				l269:
+					add(rulePegText, position268)
 				}
-				if !_rules[ruleAction27]() {
-					goto l247
+				if !_rules[ruleAction28]() {
+					goto l266
+				}
+				add(ruleNull, position267)
+			}
+			return true
+		l266:
+			position, tokenIndex = position266, tokenIndex266
+			return false
+		},
+		/* 26 Parameter <- <(':' <(([a-z] / [A-Z]) ((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('_') '_') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> Action29)> */
+		func() bool {
+			position271, tokenIndex271 := position, tokenIndex
+			{
+				position272 := position
+				if buffer[position] != rune(':') {
+					goto l271
+				}
+				position++
+				{
+					position273 := position
+					{
+						position274, tokenIndex274 := position, tokenIndex
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l275
+						}
+						position++
+						goto l274
+					l275:
+						position, tokenIndex = position274, tokenIndex274
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l271
+						}
+						position++
+					}
+				l274:
+				l276:
+					{
+						position277, tokenIndex277 := position, tokenIndex
+						{
+							switch buffer[position] {
+							case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l277
+								}
+								position++
+							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l277
+								}
+								position++
+							case '_':
+								if buffer[position] != rune('_') {
+									goto l277
+								}
+								position++
+							default:
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l277
+								}
+								position++
+							}
+						}
+
+						goto l276
+					l277:
+						position, tokenIndex = position277, tokenIndex277
+					}
+					add(rulePegText, position273)
+				}
+				if !_rules[ruleAction29]() {
+					goto l271
 				}
-				add(ruleNull, position248)
+				add(ruleParameter, position272)
 			}
 			return true
-		l247:
-			position, tokenIndex = position247, tokenIndex247
+		l271:
+			position, tokenIndex = position271, tokenIndex271
 			return false
 		},
-		/* 24 String <- <(('\'' <(!'\'' .)*> '\'' Action28) / ('"' <(!'"' .)*> '"' Action29))> */
+		/* 27 String <- <(('\'' <(('\'' '\'') / (!'\'' .))*> '\'' Action30) / ('"' <(('"' '"') / (!'"' .))*> '"' Action31))> */
 		func() bool {
-			position252, tokenIndex252 := position, tokenIndex
+			position279, tokenIndex279 := position, tokenIndex
 			{
-				position253 := position
+				position280 := position
 				{
-					position254, tokenIndex254 := position, tokenIndex
+					position281, tokenIndex281 := position, tokenIndex
 					if buffer[position] != rune('\'') {
-						goto l255
+						goto l282
 					}
 					position++
 					{
-						position256 := position
-					l257:
+						position283 := position
+					l284:
 						{
-							position258, tokenIndex258 := position, tokenIndex
+							position285, tokenIndex285 := position, tokenIndex
 							{
-								position259, tokenIndex259 := position, tokenIndex
+								position286, tokenIndex286 := position, tokenIndex
 								if buffer[position] != rune('\'') {
-									goto l259
+									goto l287
 								}
 								position++
-								goto l258
-							l259:
-								position, tokenIndex = position259, tokenIndex259
-							}
-							if !matchDot() {
-								goto l258
+								if buffer[position] != rune('\'') {
+									goto l287
+								}
+								position++
+								goto l286
+							l287:
+								position, tokenIndex = position286, tokenIndex286
+								{
+									position288, tokenIndex288 := position, tokenIndex
+									if buffer[position] != rune('\'') {
+										goto l288
+									}
+									position++
+									goto l285
+								l288:
+									position, tokenIndex = position288, tokenIndex288
+								}
+								if !matchDot() {
+									goto l285
+								}
 							}
-							goto l257
-						l258:
-							position, tokenIndex = position258, tokenIndex258
+						l286:
+							goto l284
+						l285:
+							position, tokenIndex = position285, tokenIndex285
 						}
-						add(rulePegText, position256)
+						add(rulePegText, position283)
 					}
 					if buffer[position] != rune('\'') {
-						goto l255
+						goto l282
 					}
 					position++
-					if !_rules[ruleAction28]() {
-						goto l255
+					if !_rules[ruleAction30]() {
+						goto l282
 					}
-					goto l254
-				l255:
-					position, tokenIndex = position254, tokenIndex254
+					goto l281
+				l282:
+					position, tokenIndex = position281, tokenIndex281
 					if buffer[position] != rune('"') {
-						goto l252
+						goto l279
 					}
 					position++
 					{
-						position260 := position
-					l261:
+						position289 := position
+					l290:
 						{
-							position262, tokenIndex262 := position, tokenIndex
+							position291, tokenIndex291 := position, tokenIndex
 							{
-								position263, tokenIndex263 := position, tokenIndex
+								position292, tokenIndex292 := position, tokenIndex
 								if buffer[position] != rune('"') {
-									goto l263
+									goto l293
 								}
 								position++
-								goto l262
-							l263:
-								position, tokenIndex = position263, tokenIndex263
-							}
-							if !matchDot() {
-								goto l262
+								if buffer[position] != rune('"') {
+									goto l293
+								}
+								position++
+								goto l292
+							l293:
+								position, tokenIndex = position292, tokenIndex292
+								{
+									position294, tokenIndex294 := position, tokenIndex
+									if buffer[position] != rune('"') {
+										goto l294
+									}
+									position++
+									goto l291
+								l294:
+									position, tokenIndex = position294, tokenIndex294
+								}
+								if !matchDot() {
+									goto l291
+								}
 							}
-							goto l261
-						l262:
-							position, tokenIndex = position262, tokenIndex262
+						l292:
+							goto l290
+						l291:
+							position, tokenIndex = position291, tokenIndex291
 						}
-						add(rulePegText, position260)
+						add(rulePegText, position289)
 					}
 					if buffer[position] != rune('"') {
-						goto l252
+						goto l279
 					}
 					position++
-					if !_rules[ruleAction29]() {
-						goto l252
+					if !_rules[ruleAction31]() {
+						goto l279
 					}
 				}
-			l254:
-				add(ruleString, position253)
+			l281:
+				add(ruleString, position280)
 			}
 			return true
-		l252:
-			position, tokenIndex = position252, tokenIndex252
+		l279:
+			position, tokenIndex = position279, tokenIndex279
 			return false
 		},
-		/* 25 SpaceComment <- <(Space / Comment)> */
+		/* 28 SpaceComment <- <(Space / Comment)> */
 		func() bool {
-			position264, tokenIndex264 := position, tokenIndex
+			position295, tokenIndex295 := position, tokenIndex
 			{
-				position265 := position
+				position296 := position
 				{
-					position266, tokenIndex266 := position, tokenIndex
+					position297, tokenIndex297 := position, tokenIndex
 					if !_rules[ruleSpace]() {
-						goto l267
+						goto l298
 					}
-					goto l266
-				l267:
-					position, tokenIndex = position266, tokenIndex266
+					goto l297
+				l298:
+					position, tokenIndex = position297, tokenIndex297
 					if !_rules[ruleComment]() {
-						goto l264
+						goto l295
 					}
 				}
-			l266:
-				add(ruleSpaceComment, position265)
+			l297:
+				add(ruleSpaceComment, position296)
 			}
 			return true
-		l264:
-			position, tokenIndex = position264, tokenIndex264
+		l295:
+			position, tokenIndex = position295, tokenIndex295
 			return false
 		},
-		/* 26 _ <- <SpaceComment*> */
+		/* 29 _ <- <SpaceComment*> */
 		func() bool {
 			{
-				position269 := position
-			l270:
+				position300 := position
+			l301:
 				{
-					position271, tokenIndex271 := position, tokenIndex
+					position302, tokenIndex302 := position, tokenIndex
 					if !_rules[ruleSpaceComment]() {
-						goto l271
+						goto l302
 					}
-					goto l270
-				l271:
-					position, tokenIndex = position271, tokenIndex271
+					goto l301
+				l302:
+					position, tokenIndex = position302, tokenIndex302
 				}
-				add(rule_, position269)
+				add(rule_, position300)
 			}
 			return true
 		},
-		/* 27 __ <- <SpaceComment+> */
+		/* 30 __ <- <SpaceComment+> */
 		func() bool {
-			position272, tokenIndex272 := position, tokenIndex
+			position303, tokenIndex303 := position, tokenIndex
 			{
-				position273 := position
+				position304 := position
 				if !_rules[ruleSpaceComment]() {
-					goto l272
+					goto l303
 				}
-			l274:
+			l305:
 				{
-					position275, tokenIndex275 := position, tokenIndex
+					position306, tokenIndex306 := position, tokenIndex
 					if !_rules[ruleSpaceComment]() {
-						goto l275
+						goto l306
 					}
-					goto l274
-				l275:
-					position, tokenIndex = position275, tokenIndex275
+					goto l305
+				l306:
+					position, tokenIndex = position306, tokenIndex306
 				}
-				add(rule__, position273)
+				add(rule__, position304)
 			}
 			return true
-		l272:
-			position, tokenIndex = position272, tokenIndex272
+		l303:
+			position, tokenIndex = position303, tokenIndex303
 			return false
 		},
-		/* 28 Comment <- <((('-' '-') / ('/' '/')) (!EndOfLine .)* EndOfLine)> */
+		/* 31 Comment <- <((('-' '-') / ('/' '/')) (!EndOfLine .)* EndOfLine)> */
 		func() bool {
-			position276, tokenIndex276 := position, tokenIndex
+			position307, tokenIndex307 := position, tokenIndex
 			{
-				position277 := position
+				position308 := position
 				{
-					position278, tokenIndex278 := position, tokenIndex
+					position309, tokenIndex309 := position, tokenIndex
 					if buffer[position] != rune('-') {
-						goto l279
+						goto l310
 					}
 					position++
 					if buffer[position] != rune('-') {
-						goto l279
+						goto l310
 					}
 					position++
-					goto l278
-				l279:
-					position, tokenIndex = position278, tokenIndex278
+					goto l309
+				l310:
+					position, tokenIndex = position309, tokenIndex309
 					if buffer[position] != rune('/') {
-						goto l276
+						goto l307
 					}
 					position++
 					if buffer[position] != rune('/') {
-						goto l276
+						goto l307
 					}
 					position++
 				}
-			l278:
-			l280:
+			l309:
+			l311:
 				{
-					position281, tokenIndex281 := position, tokenIndex
+					position312, tokenIndex312 := position, tokenIndex
 					{
-						position282, tokenIndex282 := position, tokenIndex
+						position313, tokenIndex313 := position, tokenIndex
 						if !_rules[ruleEndOfLine]() {
-							goto l282
+							goto l313
 						}
-						goto l281
-					l282:
-						position, tokenIndex = position282, tokenIndex282
+						goto l312
+					l313:
+						position, tokenIndex = position313, tokenIndex313
 					}
 					if !matchDot() {
-						goto l281
+						goto l312
 					}
-					goto l280
-				l281:
-					position, tokenIndex = position281, tokenIndex281
+					goto l311
+				l312:
+					position, tokenIndex = position312, tokenIndex312
 				}
 				if !_rules[ruleEndOfLine]() {
-					goto l276
+					goto l307
 				}
-				add(ruleComment, position277)
+				add(ruleComment, position308)
 			}
 			return true
-		l276:
-			position, tokenIndex = position276, tokenIndex276
+		l307:
+			position, tokenIndex = position307, tokenIndex307
 			return false
 		},
-		/* 29 Space <- <((&('\t') '\t') | (&(' ') ' ') | (&('\n' | '\r') EndOfLine))> */
+		/* 32 Space <- <((&('\t') '\t') | (&(' ') ' ') | (&('\n' | '\r') EndOfLine))> */
 		func() bool {
-			position283, tokenIndex283 := position, tokenIndex
+			position314, tokenIndex314 := position, tokenIndex
 			{
-				position284 := position
+				position315 := position
 				{
 					switch buffer[position] {
 					case '\t':
 						if buffer[position] != rune('\t') {
-							goto l283
+							goto l314
 						}
 						position++
 					case ' ':
 						if buffer[position] != rune(' ') {
-							goto l283
+							goto l314
 						}
 						position++
 					default:
 						if !_rules[ruleEndOfLine]() {
-							goto l283
+							goto l314
 						}
 					}
 				}
 
-				add(ruleSpace, position284)
+				add(ruleSpace, position315)
 			}
 			return true
-		l283:
-			position, tokenIndex = position283, tokenIndex283
+		l314:
+			position, tokenIndex = position314, tokenIndex314
 			return false
 		},
-		/* 30 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		/* 33 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
 		func() bool {
-			position286, tokenIndex286 := position, tokenIndex
+			position317, tokenIndex317 := position, tokenIndex
 			{
-				position287 := position
+				position318 := position
 				{
-					position288, tokenIndex288 := position, tokenIndex
+					position319, tokenIndex319 := position, tokenIndex
 					if buffer[position] != rune('\r') {
-						goto l289
+						goto l320
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l289
+						goto l320
 					}
 					position++
-					goto l288
-				l289:
-					position, tokenIndex = position288, tokenIndex288
+					goto l319
+				l320:
+					position, tokenIndex = position319, tokenIndex319
 					if buffer[position] != rune('\n') {
-						goto l290
+						goto l321
 					}
 					position++
-					goto l288
-				l290:
-					position, tokenIndex = position288, tokenIndex288
+					goto l319
+				l321:
+					position, tokenIndex = position319, tokenIndex319
 					if buffer[position] != rune('\r') {
-						goto l286
+						goto l317
 					}
 					position++
 				}
-			l288:
-				add(ruleEndOfLine, position287)
+			l319:
+				add(ruleEndOfLine, position318)
 			}
 			return true
-		l286:
-			position, tokenIndex = position286, tokenIndex286
+		l317:
+			position, tokenIndex = position317, tokenIndex317
 			return false
 		},
-		/* 31 EndOfFile <- <!.> */
+		/* 34 EndOfFile <- <!.> */
 		func() bool {
-			position291, tokenIndex291 := position, tokenIndex
+			position322, tokenIndex322 := position, tokenIndex
 			{
-				position292 := position
+				position323 := position
 				{
-					position293, tokenIndex293 := position, tokenIndex
+					position324, tokenIndex324 := position, tokenIndex
 					if !matchDot() {
-						goto l293
+						goto l324
 					}
-					goto l291
-				l293:
-					position, tokenIndex = position293, tokenIndex293
+					goto l322
+				l324:
+					position, tokenIndex = position324, tokenIndex324
 				}
-				add(ruleEndOfFile, position292)
+				add(ruleEndOfFile, position323)
 			}
 			return true
-		l291:
-			position, tokenIndex = position291, tokenIndex291
+		l322:
+			position, tokenIndex = position322, tokenIndex322
 			return false
 		},
-		/* 33 Action0 <- <{p.PopLogic()}> */
+		/* 36 Action0 <- <{p.PopLogic()}> */
 		func() bool {
 			{
 				add(ruleAction0, position)
 			}
 			return true
 		},
-		/* 34 Action1 <- <{p.PopNot()}> */
+		/* 37 Action1 <- <{p.PopNot()}> */
 		func() bool {
 			{
 				add(ruleAction1, position)
 			}
 			return true
 		},
-		/* 35 Action2 <- <{p.PopCompare()}> */
+		/* 38 Action2 <- <{p.PopCompare()}> */
 		func() bool {
 			{
 				add(ruleAction2, position)
@@ -3168,195 +3427,209 @@ func (p *MiniQueryPeg) Init(options ...func(*MiniQueryPeg) error) error {
 			return true
 		},
 		nil,
-		/* 37 Action3 <- <{p.AddCompare(text)}> */
+		/* 40 Action3 <- <{p.AddCompare(text)}> */
 		func() bool {
 			{
 				add(ruleAction3, position)
 			}
 			return true
 		},
-		/* 38 Action4 <- <{p.PopCompare()}> */
+		/* 41 Action4 <- <{p.PopCompare()}> */
 		func() bool {
 			{
 				add(ruleAction4, position)
 			}
 			return true
 		},
-		/* 39 Action5 <- <{p.PopPredicate()}> */
+		/* 42 Action5 <- <{p.PopPredicate()}> */
 		func() bool {
 			{
 				add(ruleAction5, position)
 			}
 			return true
 		},
-		/* 40 Action6 <- <{p.AddOperation(text)}> */
+		/* 43 Action6 <- <{p.AddOperation(text)}> */
 		func() bool {
 			{
 				add(ruleAction6, position)
 			}
 			return true
 		},
-		/* 41 Action7 <- <{p.PopBetween()}> */
+		/* 44 Action7 <- <{p.PopBetween()}> */
 		func() bool {
 			{
 				add(ruleAction7, position)
 			}
 			return true
 		},
-		/* 42 Action8 <- <{p.PopParentheses()}> */
+		/* 45 Action8 <- <{p.PopParentheses()}> */
 		func() bool {
 			{
 				add(ruleAction8, position)
 			}
 			return true
 		},
-		/* 43 Action9 <- <{p.PopFunction()}> */
+		/* 46 Action9 <- <{p.PopFunction()}> */
 		func() bool {
 			{
 				add(ruleAction9, position)
 			}
 			return true
 		},
-		/* 44 Action10 <- <{p.AddMark()}> */
+		/* 47 Action10 <- <{p.AddMark()}> */
 		func() bool {
 			{
 				add(ruleAction10, position)
 			}
 			return true
 		},
-		/* 45 Action11 <- <{p.PopArray()}> */
+		/* 48 Action11 <- <{p.PopArray()}> */
 		func() bool {
 			{
 				add(ruleAction11, position)
 			}
 			return true
 		},
-		/* 46 Action12 <- <{p.AddMark()}> */
+		/* 49 Action12 <- <{p.AddMark()}> */
 		func() bool {
 			{
 				add(ruleAction12, position)
 			}
 			return true
 		},
-		/* 47 Action13 <- <{p.PopIdentifierReference()}> */
+		/* 50 Action13 <- <{p.PopIdentifierReference()}> */
 		func() bool {
 			{
 				add(ruleAction13, position)
 			}
 			return true
 		},
-		/* 48 Action14 <- <{p.AddName(text)}> */
+		/* 51 Action14 <- <{p.AddName(text)}> */
 		func() bool {
 			{
 				add(ruleAction14, position)
 			}
 			return true
 		},
-		/* 49 Action15 <- <{p.AddCompare(text)}> */
+		/* 52 Action15 <- <{p.AddCompare(text)}> */
 		func() bool {
 			{
 				add(ruleAction15, position)
 			}
 			return true
 		},
-		/* 50 Action16 <- <{p.AddCompare(text)}> */
+		/* 53 Action16 <- <{p.AddCompare(text)}> */
 		func() bool {
 			{
 				add(ruleAction16, position)
 			}
 			return true
 		},
-		/* 51 Action17 <- <{p.AddCompare(text)}> */
+		/* 54 Action17 <- <{p.AddCompare(text)}> */
 		func() bool {
 			{
 				add(ruleAction17, position)
 			}
 			return true
 		},
-		/* 52 Action18 <- <{p.AddLogic(text)}> */
+		/* 55 Action18 <- <{p.AddLogic(text)}> */
 		func() bool {
 			{
 				add(ruleAction18, position)
 			}
 			return true
 		},
-		/* 53 Action19 <- <{p.AddLogic(text)}> */
+		/* 56 Action19 <- <{p.AddLogic(text)}> */
 		func() bool {
 			{
 				add(ruleAction19, position)
 			}
 			return true
 		},
-		/* 54 Action20 <- <{p.AddMatch(text)}> */
+		/* 57 Action20 <- <{p.AddMatch(text)}> */
 		func() bool {
 			{
 				add(ruleAction20, position)
 			}
 			return true
 		},
-		/* 55 Action21 <- <{p.AddMark()}> */
+		/* 58 Action21 <- <{p.AddMark()}> */
 		func() bool {
 			{
 				add(ruleAction21, position)
 			}
 			return true
 		},
-		/* 56 Action22 <- <{p.PopArray()}> */
+		/* 59 Action22 <- <{p.PopArray()}> */
 		func() bool {
 			{
 				add(ruleAction22, position)
 			}
 			return true
 		},
-		/* 57 Action23 <- <{p.AddMark()}> */
+		/* 60 Action23 <- <{p.AddMark()}> */
 		func() bool {
 			{
 				add(ruleAction23, position)
 			}
 			return true
 		},
-		/* 58 Action24 <- <{p.PopArray()}> */
+		/* 61 Action24 <- <{p.PopArray()}> */
 		func() bool {
 			{
 				add(ruleAction24, position)
 			}
 			return true
 		},
-		/* 59 Action25 <- <{p.AddInteger(text)}> */
+		/* 62 Action25 <- <{p.AddFloat(text)}> */
 		func() bool {
 			{
 				add(ruleAction25, position)
 			}
 			return true
 		},
-		/* 60 Action26 <- <{p.AddBoolean(text)}> */
+		/* 63 Action26 <- <{p.AddInteger(text)}> */
 		func() bool {
 			{
 				add(ruleAction26, position)
 			}
 			return true
 		},
-		/* 61 Action27 <- <{p.AddNull()}> */
+		/* 64 Action27 <- <{p.AddBoolean(text)}> */
 		func() bool {
 			{
 				add(ruleAction27, position)
 			}
 			return true
 		},
-		/* 62 Action28 <- <{p.AddString(text)}> */
+		/* 65 Action28 <- <{p.AddNull()}> */
 		func() bool {
 			{
 				add(ruleAction28, position)
 			}
 			return true
 		},
-		/* 63 Action29 <- <{p.AddString(text)}> */
+		/* 66 Action29 <- <{p.AddParameter(text)}> */
 		func() bool {
 			{
 				add(ruleAction29, position)
 			}
 			return true
 		},
+		/* 67 Action30 <- <{p.AddString(unquote(text, '\''))}> */
+		func() bool {
+			{
+				add(ruleAction30, position)
+			}
+			return true
+		},
+		/* 68 Action31 <- <{p.AddString(unquote(text, '"'))}> */
+		func() bool {
+			{
+				add(ruleAction31, position)
+			}
+			return true
+		},
 	}
 	p.rules = _rules
 	return nil
@@ -2,7 +2,12 @@ package miniquery
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type (
@@ -22,6 +27,7 @@ const (
 	NotExpressionType         NodeType = "not"         // Node.Expression
 	BetweenExpressionType     NodeType = "between"     // Node.Left, Node.Op, Node.Params
 	FunctionExpressionType    NodeType = "function"    // Node.Name, Node.Params
+	ParameterNodeType         NodeType = "parameter"   // :name - Node.Name, bound by Expand
 )
 
 const (
@@ -31,6 +37,8 @@ const (
 	StringValueType  ValueType = "string"
 	NullValueType    ValueType = "null"
 	ArrayValueType   ValueType = "array"
+	TimeValueType    ValueType = "time" // coerced by Check
+	UUIDValueType    ValueType = "uuid" // coerced by Check, Node.UUID or Node.Str
 )
 
 type Node struct {
@@ -40,6 +48,8 @@ type Node struct {
 	Bool      bool
 	Str       string
 	Float     float64
+	Time      time.Time
+	UUID      uuid.UUID // parsed uuid of UUIDValueType
 	Array     []*Node
 
 	Operation OpType
@@ -66,6 +76,16 @@ func (n Node) Value() interface{} {
 		return n.Bool
 	case NullValueType:
 		return nil
+	case TimeValueType:
+		return n.Time
+	case UUIDValueType:
+		if n.UUID != uuid.Nil {
+			return n.UUID
+		}
+		if id, err := uuid.Parse(n.Str); err == nil {
+			return id
+		}
+		return n.Str
 	case ArrayValueType:
 		var s []interface{}
 		for _, v := range n.Array {
@@ -76,13 +96,40 @@ func (n Node) Value() interface{} {
 	return "<unknown value>"
 }
 
+// Clone deep copy the node
+func (n *Node) Clone() *Node {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.Names = slices.Clone(n.Names)
+	c.Expression = n.Expression.Clone()
+	c.Left = n.Left.Clone()
+	c.Op = n.Op.Clone()
+	c.Right = n.Right.Clone()
+	c.Array = cloneNodes(n.Array)
+	c.Params = cloneNodes(n.Params)
+	return &c
+}
+
+func cloneNodes(nodes []*Node) []*Node {
+	if nodes == nil {
+		return nil
+	}
+	out := make([]*Node, len(nodes))
+	for i, v := range nodes {
+		out[i] = v.Clone()
+	}
+	return out
+}
+
 func (n Node) IsValue() bool {
 	return n.ValueType != ""
 }
 
 func (n Node) IsExpression() bool {
 	switch n.Type {
-	case IdentifierNodeType, ValueNodeType, OperationNodeType, ReferenceNodeType:
+	case IdentifierNodeType, ValueNodeType, OperationNodeType, ReferenceNodeType, ParameterNodeType:
 		return false
 	}
 	return true
@@ -120,7 +167,7 @@ func (n Node) String() string {
 		_, _ = fmt.Fprint(buf, n.Value())
 	case OperationNodeType:
 		buf.WriteString(n.Operation)
-	case IdentifierNodeType:
+	case IdentifierNodeType, ParameterNodeType:
 		buf.WriteString(n.Name)
 	case ReferenceNodeType:
 		buf.WriteString(strings.Join(n.Names, "."))
@@ -173,10 +220,13 @@ func Build(n *Node) string {
 			buf.WriteString(printPretty(node.Operation))
 		case IdentifierNodeType:
 			buf.WriteString(node.Name)
+		case ParameterNodeType:
+			buf.WriteRune(':')
+			buf.WriteString(node.Name)
 		case ReferenceNodeType:
 			buf.WriteString(strings.Join(node.Names, "."))
 		case FunctionExpressionType:
-			buf.WriteString(n.Name)
+			buf.WriteString(node.Name)
 			buf.WriteRune('(')
 			n := len(node.Params)
 			for i, v := range node.Params {
@@ -196,6 +246,15 @@ func Build(n *Node) string {
 	return buf.String()
 }
 
+// Quote quote string as literal of query, double quoted unless contains double quote,
+// quote inside single quoted literal is escaped by doubling like SQL, there are no other escapes
+func Quote(s string) string {
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
 func buildValue(buf *strings.Builder, node *Node, visit func(node *Node)) {
 	switch node.ValueType {
 	case ArrayValueType:
@@ -209,7 +268,18 @@ func buildValue(buf *strings.Builder, node *Node, visit func(node *Node)) {
 		}
 		buf.WriteRune(']')
 	case StringValueType:
-		_, _ = fmt.Fprintf(buf, "%q", node.Str)
+		buf.WriteString(Quote(node.Str))
+	case UUIDValueType:
+		buf.WriteString(Quote(fmt.Sprint(node.Value())))
+	case TimeValueType:
+		buf.WriteString(Quote(node.Time.Format(time.RFC3339Nano)))
+	case FloatValueType:
+		// always with decimal point so it is parsed back as float
+		s := strconv.FormatFloat(node.Float, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		buf.WriteString(s)
 	default:
 		value := node.Value()
 		if value == nil {
@@ -240,6 +310,10 @@ const (
 	OpNot        OpType = "not"
 	OpIn         OpType = "in"
 	OpNotIn      OpType = "not in"
+	OpIsTrue     OpType = "is true"
+	OpIsNotTrue  OpType = "is not true"
+	OpIsFalse    OpType = "is false"
+	OpIsNotFalse OpType = "is not false"
 	// IS UNKNOWN, TRUE, FALSE, DISTINCT FROM
 	// BETWEEN SYMMETRIC
 )
@@ -0,0 +1,206 @@
+package miniquery
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NullsOrder placement of NULL in order, empty use the database default
+type NullsOrder string
+
+const (
+	NullsFirst NullsOrder = "first"
+	NullsLast  NullsOrder = "last"
+)
+
+// Order sort term of order string
+type Order struct {
+	// Field identifier, reference or function like date(created_at)
+	Field *Node
+	Desc  bool
+	Nulls NullsOrder
+}
+
+func (o *Order) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(Build(o.Field))
+	if o.Desc {
+		sb.WriteString(" desc")
+	} else {
+		sb.WriteString(" asc")
+	}
+	if o.Nulls != "" {
+		sb.WriteString(" nulls ")
+		sb.WriteString(string(o.Nulls))
+	}
+	return sb.String()
+}
+
+// ParseOrder parse order string like `createdAt desc, Profile.age asc nulls last`,
+// terms use the filter syntax for identifier, reference and function, direction default asc.
+func ParseOrder(s string) ([]*Order, error) {
+	var out []*Order
+	for _, term := range splitTopLevel(s) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		o, err := parseOrderTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	return out, nil
+}
+
+func parseOrderTerm(term string) (*Order, error) {
+	o := &Order{}
+	expr := term
+	fields := strings.Fields(term)
+	n := len(fields)
+	if n >= 2 && strings.EqualFold(fields[n-2], "nulls") {
+		switch strings.ToLower(fields[n-1]) {
+		case "first":
+			o.Nulls = NullsFirst
+		case "last":
+			o.Nulls = NullsLast
+		default:
+			return nil, NewError(ErrInvalidOrder).WithValue(term)
+		}
+		expr = trimLastWords(expr, 2)
+		n -= 2
+	}
+	if n >= 2 {
+		switch strings.ToLower(fields[n-1]) {
+		case "desc":
+			o.Desc = true
+			expr = trimLastWords(expr, 1)
+		case "asc":
+			expr = trimLastWords(expr, 1)
+		}
+	}
+	node, err := Parse(expr)
+	if err != nil {
+		return nil, NewError(ErrInvalidOrder).WithValue(term).Wrap(err)
+	}
+	switch node.Type {
+	case IdentifierNodeType, ReferenceNodeType:
+	case FunctionExpressionType:
+		if RelationFunctions[node.Name] {
+			return nil, NewError(ErrInvalidOrder).WithValue(term)
+		}
+	default:
+		return nil, NewError(ErrInvalidOrder).WithValue(term)
+	}
+	o.Field = node
+	return o, nil
+}
+
+// CheckOrder check order fields exist in schema and can be sorted,
+// json fields and fields of to-many relations are not sortable
+func CheckOrder(orders []*Order, schema *Schema) error {
+	for _, o := range orders {
+		if err := checkOrderField(o.Field, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckKeyset check order of cursor pagination has a unique not null field of the model,
+// rows of tied values are skipped or repeated between pages otherwise
+func CheckKeyset(orders []*Order, schema *Schema) error {
+	var unique []string
+	for _, f := range schema.Fields {
+		if f.Unique && !f.Nullable {
+			unique = append(unique, strconv.Quote(f.Name))
+		}
+	}
+	for _, o := range orders {
+		if o.Field.Type != IdentifierNodeType {
+			continue
+		}
+		if f := schema.Field(o.Field.Name); f != nil && f.Unique && !f.Nullable {
+			return nil
+		}
+	}
+	expected := "unique field"
+	if len(unique) != 0 {
+		expected = strings.Join(unique, " or ")
+	}
+	return NewError(ErrNonUniqueOrder).WithValue(orderKey(orders)).WithExpected(expected)
+}
+
+func checkOrderField(node *Node, s *Schema) error {
+	var names []string
+	switch node.Type {
+	case IdentifierNodeType:
+		names = []string{node.Name}
+	case ReferenceNodeType:
+		names = node.Names
+	case FunctionExpressionType:
+		for _, v := range node.Params {
+			if err := checkOrderField(v, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+	cur := s
+	for i, name := range names[:len(names)-1] {
+		if cur.IsToMany(name) {
+			return NewError(ErrUnsortableField).WithField(names[:i+1]...)
+		}
+		if cur = cur.Relation(name); cur == nil {
+			break
+		}
+	}
+	f, err := s.Lookup(names)
+	if err != nil {
+		return err
+	}
+	if f.Type == JSONFieldType {
+		return NewError(ErrUnsortableField).WithField(names...)
+	}
+	return nil
+}
+
+// splitTopLevel split by comma outside of parentheses and quotes
+func splitTopLevel(s string) []string {
+	var out []string
+	depth, start := 0, 0
+	var quote rune
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+func trimLastWords(s string, n int) string {
+	s = strings.TrimSpace(s)
+	for ; n > 0; n-- {
+		i := strings.LastIndexAny(s, " \t\r\n")
+		if i < 0 {
+			return ""
+		}
+		s = strings.TrimSpace(s[:i])
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+package miniquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOrder(t *testing.T) {
+	for _, test := range []struct {
+		S   string
+		E   []string
+		Err bool
+	}{
+		{S: ``},
+		{S: `name`, E: []string{"name asc"}},
+		{S: `createdAt DESC, Profile.age asc`, E: []string{"createdAt desc", "Profile.age asc"}},
+		{S: `name, , id desc`, E: []string{"name asc", "id desc"}},
+		{S: `name up`, Err: true},
+		{S: `a = 1`, Err: true},
+		{S: `1 desc`, Err: true},
+		{S: `Profile.age asc nulls last, createdAt desc NULLS FIRST`, E: []string{"Profile.age asc nulls last", "createdAt desc nulls first"}},
+		{S: `age nulls last`, E: []string{"age asc nulls last"}},
+		{S: `date(created_at) desc`, E: []string{"date(created_at) desc"}},
+		{S: `age nulls middle`, Err: true},
+		{S: `has_edge(orders) desc`, Err: true},
+	} {
+		orders, err := ParseOrder(test.S)
+		if test.Err {
+			assert.Error(t, err, test.S)
+			continue
+		}
+		assert.NoError(t, err, test.S)
+		var out []string
+		for _, v := range orders {
+			out = append(out, v.String())
+		}
+		assert.Equal(t, test.E, out, test.S)
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	schema := &Schema{
+		Fields: []*SchemaField{
+			{Name: "name", Type: StringFieldType},
+			{Name: "createdAt", Column: "created_at", Type: TimeFieldType},
+			{Name: "meta", Type: JSONFieldType},
+		},
+		Relations: map[string]*Schema{
+			"Profile": {Fields: []*SchemaField{{Name: "age", Type: IntFieldType}}},
+			"Orders":  {Fields: []*SchemaField{{Name: "total", Type: FloatFieldType}}},
+		},
+		ToMany: []string{"Orders"},
+	}
+	for _, test := range []struct {
+		S   string
+		Err error
+	}{
+		{S: `name, created_at desc, Profile.age nulls last`},
+		{S: `date(createdAt)`},
+		{S: `meta`, Err: ErrUnsortableField},
+		{S: `Orders.total`, Err: ErrUnsortableField},
+		{S: `nmae`, Err: ErrUnknownField},
+		{S: `Profle.age`, Err: ErrUnknownRelation},
+	} {
+		orders, err := ParseOrder(test.S)
+		assert.NoError(t, err, test.S)
+		err = CheckOrder(orders, schema)
+		if test.Err == nil {
+			assert.NoError(t, err, test.S)
+		} else {
+			assert.ErrorIs(t, err, test.Err, test.S)
+		}
+	}
+}
@@ -1,16 +1,36 @@
 package miniquery
 
+import "errors"
+
 func Parse(s string) (*Node, error) {
 	p := &MiniQueryPeg{Tree: &Tree{}, Buffer: s}
 	if err := p.Init(); err != nil {
 		return nil, err
 	}
 	if err := p.Parse(); err != nil {
-		return nil, err
+		return nil, syntaxError(s, err)
 	}
 	p.Execute()
 	if len(p.Errors) != 0 {
-		return nil, p.Errors[0]
+		return nil, syntaxError(s, p.Errors[0])
 	}
 	return p.Pop(), nil
 }
+
+// syntaxError locate the farthest matched position of parse error
+func syntaxError(s string, err error) error {
+	e := NewError(ErrSyntax).WithValue(s).Wrap(err)
+	var pe *parseError
+	if errors.As(err, &pe) {
+		// rune index to byte offset
+		offset := len(string(pe.p.buffer[:min(int(pe.max.end), len([]rune(s)))]))
+		near := s[offset:]
+		if r := []rune(near); len(r) > 16 {
+			near = string(r[:16])
+		}
+		e.WithOffset(offset).WithValue(near)
+		// the generated message is verbose
+		e.Err = nil
+	}
+	return e
+}
@@ -74,6 +74,8 @@ func TestParsing(t *testing.T) {
 		`func( a, name in ('a','b') )`,
 		`date('2021-05-12T00:00:00+08:00')`,
 		`profile.age > 10`,
+		`owner_id = :currentUser`,
+		`a in (:a, :b) and notes is null and note = 1`,
 		// unsupported
 		// `date(created_at) between date('2021-05-12T00:00:00+08:00') and date('2021-05-14T00:00:00+08:00')`,
 	} {
@@ -100,6 +102,24 @@ func TestParsing(t *testing.T) {
 	}
 }
 
+func TestQuote(t *testing.T) {
+	for _, v := range []string{``, `wener`, `C:\dir`, "tab\there", `a"b`, `it's`, `it's "x"`, `''""`} {
+		n, err := Parse(`a = ` + Quote(v))
+		if assert.NoError(t, err, v) {
+			assert.Equal(t, v, n.Right.Str)
+		}
+		q := Build(n)
+		n, err = Parse(q)
+		if assert.NoError(t, err, q) {
+			assert.Equal(t, v, n.Right.Str)
+			assert.Equal(t, q, Build(n))
+		}
+	}
+	n, err := Parse(`a = 'it''s' and b = "say ""hi"""`)
+	assert.NoError(t, err)
+	assert.Equal(t, `a == "it's" && b == 'say "hi"'`, Build(n))
+}
+
 func TestParse(t *testing.T) {
 	// p := &MiniQueryPeg{Tree: &Tree{}, Pretty: true, Buffer: `profile.age > 10`}
 	p := &MiniQueryPeg{Tree: &Tree{}, Pretty: true, Buffer: "a > 1 and  (  b != 2 or name like '%wener%') or ( age == 0 )"}
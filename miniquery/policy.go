@@ -0,0 +1,313 @@
+package miniquery
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Policy which fields and relations can be filtered and the operators allowed for each,
+// hidden fields are reported the same as missing fields.
+//
+//	email: eq,in; bio: contains; Profile.*; -password_hash; date(); has_edge()
+type Policy struct {
+	// Fields allowed field paths to operators, nil operators allow all.
+	// "*" allow every field, "Profile.*" allow every field of relation Profile.
+	// nil Fields allow every field not denied.
+	Fields map[string][]OpType
+	// Deny field paths always rejected, take precedence over Fields
+	Deny []string
+	// Functions allowed functions, nil allow all, sorted and deduplicated by ParsePolicy
+	Functions []string
+	// Schema canonicalize field names when not nil, a field match entries by either its name or column,
+	// so `-Address.City` also hide `address_city`
+	Schema *Schema
+}
+
+var policyOperatorAlias = map[string][]OpType{
+	"contains": {OpLike, OpNotLike},
+	"null":     {OpIsNull, OpIsNotNull},
+	"between":  {OpBetween, OpNotBetween},
+}
+
+// ParsePolicy parse policy spec, entries are separated by ';' or new line,
+// an entry is `path[: op,op...]`, prefix `-` to deny, `name()` allow the function.
+func ParsePolicy(spec string) (*Policy, error) {
+	p := &Policy{}
+	for _, line := range strings.FieldsFunc(spec, func(r rune) bool { return r == ';' || r == '\n' }) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if name, ok := strings.CutPrefix(line, "-"); ok {
+			p.Deny = append(p.Deny, strings.TrimSpace(name))
+			continue
+		}
+		if fn, ok := strings.CutSuffix(line, "()"); ok {
+			if fn = strings.TrimSpace(fn); fn == "" {
+				return nil, NewError(ErrInvalidPolicy).WithValue(line)
+			}
+			p.Functions = append(p.Functions, fn)
+			continue
+		}
+		name, ops, hasOps := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, NewError(ErrInvalidPolicy).WithValue(line)
+		}
+		if p.Fields == nil {
+			p.Fields = map[string][]OpType{}
+		}
+		if !hasOps {
+			p.Fields[name] = nil
+			continue
+		}
+		allowed := []OpType{}
+		for _, op := range strings.Split(ops, ",") {
+			op = NormalizeOperation(op)
+			if op == "" {
+				continue
+			}
+			if v, ok := policyOperatorAlias[op]; ok {
+				allowed = append(allowed, v...)
+			} else {
+				allowed = append(allowed, op)
+			}
+		}
+		p.Fields[name] = allowed
+	}
+	if p.Functions != nil {
+		slices.Sort(p.Functions)
+		p.Functions = slices.Compact(p.Functions)
+	}
+	return p, nil
+}
+
+// WithSchema return copy of policy canonicalize field names by schema, p is returned if nil or already has schema
+func (p *Policy) WithSchema(s *Schema) *Policy {
+	if p == nil || s == nil || p.Schema != nil {
+		return p
+	}
+	c := *p
+	c.Schema = s
+	return &c
+}
+
+// Enforce check the query only use allowed fields, relations, operators and functions
+func (p *Policy) Enforce(node *Node) error {
+	if p == nil || node == nil {
+		return nil
+	}
+	return p.enforce(node, nil, "")
+}
+
+// AllowField check whether field path can be filtered by op, empty op only check visibility
+func (p *Policy) AllowField(names []string, op OpType) bool {
+	paths := p.fieldPaths(names)
+	for _, path := range paths {
+		if p.denied(path) {
+			return false
+		}
+	}
+	if p.Fields == nil {
+		return true
+	}
+	for k, ops := range p.Fields {
+		if !slices.ContainsFunc(paths, func(path string) bool { return matchPolicyPath(foldName(k), path) }) {
+			continue
+		}
+		if op == "" || ops == nil || slices.Contains(ops, op) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldPaths folded paths of field, the path by field name and column of Schema are included when found
+func (p *Policy) fieldPaths(names []string) []string {
+	paths := []string{foldPath(names)}
+	if p.Schema == nil {
+		return paths
+	}
+	cur := p.Schema
+	i := 0
+	for ; i < len(names)-1; i++ {
+		next := cur.Relation(names[i])
+		if next == nil {
+			break
+		}
+		cur = next
+	}
+	// fields of embedded struct are named by path
+	f := cur.Field(strings.Join(names[i:], "."))
+	if f == nil {
+		return paths
+	}
+	for _, name := range []string{f.Name, f.Column} {
+		if name == "" {
+			continue
+		}
+		path := foldPath(append(slices.Clone(names[:i]), name))
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// AllowRelation check whether fields of relation path can be filtered
+func (p *Policy) AllowRelation(names []string) bool {
+	path := foldPath(names)
+	if p.denied(path) {
+		return false
+	}
+	if p.Fields == nil {
+		return true
+	}
+	for k := range p.Fields {
+		k = foldName(k)
+		if k == "*" || matchPolicyPath(k, path) || strings.HasPrefix(k, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// denied check folded path against Deny, denied relation hide all of its fields
+func (p *Policy) denied(path string) bool {
+	for _, v := range p.Deny {
+		v = foldName(v)
+		if matchPolicyPath(v, path) || strings.HasPrefix(path, v+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowFunction check whether function can be used
+func (p *Policy) AllowFunction(name string) bool {
+	return p.Functions == nil || slices.Contains(p.Functions, name)
+}
+
+// Redact drop suggestions of fields and relations hidden by the policy from unknown field or relation error,
+// err is returned as is otherwise
+func (p *Policy) Redact(err error) error {
+	var e *Error
+	if p == nil || !errors.As(err, &e) || len(e.Suggestions) == 0 {
+		return err
+	}
+	var allow func(names []string) bool
+	switch e.Code {
+	case ErrUnknownField:
+		allow = func(names []string) bool { return p.AllowField(names, "") }
+	case ErrUnknownRelation:
+		allow = p.AllowRelation
+	default:
+		return err
+	}
+	parts := strings.Split(e.Field, ".")
+	var out []string
+	for _, v := range e.Suggestions {
+		// suggestion replace the last names of the path, embedded field is suggested by path
+		names := strings.Split(v, ".")
+		if len(names) > len(parts) {
+			continue
+		}
+		if allow(append(slices.Clone(parts[:len(parts)-len(names)]), names...)) {
+			out = append(out, v)
+		}
+	}
+	e.Suggestions = out
+	return err
+}
+
+func (p *Policy) enforce(node *Node, prefix []string, op OpType) error {
+	switch node.Type {
+	case ValueNodeType, OperationNodeType, ParameterNodeType:
+		return nil
+	case IdentifierNodeType:
+		return p.enforceField(append(slices.Clone(prefix), node.Name), op)
+	case ReferenceNodeType:
+		return p.enforceField(append(slices.Clone(prefix), node.Names...), op)
+	case ParenthesesExpressionType, NotExpressionType:
+		return p.enforce(node.Expression, prefix, op)
+	case LogicExpressionType:
+		if err := p.enforce(node.Left, prefix, op); err != nil {
+			return err
+		}
+		return p.enforce(node.Right, prefix, op)
+	case FunctionExpressionType:
+		return p.enforceFunction(node, prefix, op)
+	}
+	// compare, between, predicates
+	op = node.Op.Operation
+	for _, v := range append([]*Node{node.Left, node.Right}, node.Params...) {
+		if v == nil {
+			continue
+		}
+		if err := p.enforce(v, prefix, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Policy) enforceFunction(node *Node, prefix []string, op OpType) error {
+	if !p.AllowFunction(node.Name) {
+		e := NewError(ErrFunctionNotAllowed).WithFunction(node.Name).WithSuggestions(node.Name, p.Functions)
+		if len(e.Suggestions) == 0 {
+			// not a typo, hint the allowed functions
+			e.Suggestions = slices.Sorted(slices.Values(p.Functions))
+		}
+		return e
+	}
+	params := node.Params
+	if RelationFunctions[node.Name] && len(params) > 0 && params[0].Type == IdentifierNodeType {
+		rel := append(slices.Clone(prefix), params[0].Name)
+		if !p.AllowRelation(rel) {
+			return NewError(ErrUnknownRelation).WithField(rel...)
+		}
+		for _, v := range params[1:] {
+			if err := p.enforce(v, rel, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, v := range params {
+		if err := p.enforce(v, prefix, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Policy) enforceField(names []string, op OpType) error {
+	// same error as missing field, hidden fields should not be discoverable
+	if !p.AllowField(names, "") {
+		return NewError(ErrUnknownField).WithField(names...)
+	}
+	if op != "" && !p.AllowField(names, op) {
+		return NewError(ErrOperatorNotAllowed).WithField(names...).WithOperator(op)
+	}
+	return nil
+}
+
+func foldPath(names []string) string {
+	out := make([]string, len(names))
+	for i, v := range names {
+		out[i] = foldName(v)
+	}
+	return strings.Join(out, ".")
+}
+
+// matchPolicyPath match folded policy pattern, pattern "*" or "rel.*" match every field under the prefix
+func matchPolicyPath(pattern, path string) bool {
+	if pattern == "*" || pattern == path {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(path, prefix+".")
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package miniquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	p, err := ParsePolicy(`
+email: eq,in
+bio: contains
+username
+Profile.*
+Orders.total: >, <
+-Profile.secret
+has_edge(); date()
+date()
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"date", "has_edge"}, p.Functions)
+	for _, test := range []struct {
+		Q   string
+		Err string
+	}{
+		{Q: `email = 'a' or email in ['a','b']`},
+		{Q: `bio like '%a%' and username like 'a%'`},
+		{Q: `profile.age > 1 and Profile.name is null`},
+		{Q: `has_edge(Orders, total > 1)`},
+		{Q: `has_edge(Profile)`},
+		{Q: `date(username) = '2021-01-01'`},
+		{Q: `username = :me and email in (:a, :b) and has_edge(Orders, total > :min)`},
+		{Q: `email like :pattern`, Err: `operator "like" not allowed for field "email"`},
+		{Q: `email like '%a%'`, Err: `operator "like" not allowed for field "email"`},
+		{Q: `password_hash like 'a%'`, Err: `field not found: "password_hash"`},
+		{Q: `missing = 1`, Err: `field not found: "missing"`},
+		{Q: `Profile.secret = 1`, Err: `field not found: "Profile.secret"`},
+		{Q: `has_edge(Orders, total = 1)`, Err: `operator "eq" not allowed for field "Orders.total"`},
+		{Q: `has_edge(Orders, user_id = 1)`, Err: `field not found: "Orders.user_id"`},
+		{Q: `has_edge(Friends)`, Err: `relation not found: "Friends"`},
+		{Q: `lower(username) = 'a'`, Err: `function not allowed: "lower", did you mean "date", "has_edge"?`},
+		{Q: `dat(username) = 'a'`, Err: `function not allowed: "dat", did you mean "date"?`},
+	} {
+		n, err := Parse(test.Q)
+		if !assert.NoError(t, err, test.Q) {
+			continue
+		}
+		err = p.Enforce(n)
+		if test.Err == "" {
+			assert.NoError(t, err, test.Q)
+		} else {
+			assert.EqualError(t, err, test.Err, test.Q)
+		}
+	}
+
+	deny := &Policy{Deny: []string{"password_hash", "Profile"}}
+	n, _ := Parse(`username = 1 and PasswordHash = 1`)
+	assert.EqualError(t, deny.Enforce(n), `field not found: "PasswordHash"`)
+	n, _ = Parse(`Profile.age > 1`)
+	assert.EqualError(t, deny.Enforce(n), `field not found: "Profile.age"`)
+
+	var none *Policy
+	assert.NoError(t, none.Enforce(n))
+
+	_, err = ParsePolicy(`username; ()`)
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+	p, err = ParsePolicy(`username`)
+	assert.NoError(t, err)
+	assert.Nil(t, p.Functions)
+}
+
+func TestPolicySchema(t *testing.T) {
+	schema := &Schema{
+		Fields: []*SchemaField{
+			{Name: "FullName", Column: "display_name", Type: StringFieldType},
+			{Name: "Address.City", Column: "address_city", Type: StringFieldType},
+		},
+		Relations: map[string]*Schema{
+			"Profile": {Fields: []*SchemaField{{Name: "Secret", Column: "secret_value", Type: StringFieldType}}},
+		},
+	}
+	for _, test := range []struct {
+		Policy *Policy
+		Q      string
+		Err    string
+	}{
+		{Policy: &Policy{Deny: []string{"FullName"}}, Q: `display_name = 'x'`, Err: `field not found: "display_name"`},
+		{Policy: &Policy{Deny: []string{"display_name"}}, Q: `FullName = 'x'`, Err: `field not found: "FullName"`},
+		{Policy: &Policy{Deny: []string{"Address.City"}}, Q: `address_city = 'x'`, Err: `field not found: "address_city"`},
+		{Policy: &Policy{Deny: []string{"address_city"}}, Q: `Address.City = 'x'`, Err: `field not found: "Address.City"`},
+		{Policy: &Policy{Deny: []string{"Profile.secret_value"}}, Q: `profile.secret = 'x'`, Err: `field not found: "profile.secret"`},
+		{Policy: &Policy{Fields: map[string][]OpType{"display_name": {OpEQ}}}, Q: `FullName = 'x'`},
+		{Policy: &Policy{Fields: map[string][]OpType{"display_name": {OpEQ}}}, Q: `FullName like 'x'`, Err: `operator "like" not allowed for field "FullName"`},
+		{Policy: &Policy{Deny: []string{"FullName"}}, Q: `missing = 'x'`},
+	} {
+		n, err := Parse(test.Q)
+		if !assert.NoError(t, err, test.Q) {
+			continue
+		}
+		// denied field is bypassed by the other name without schema
+		if test.Err != "" && test.Policy.Fields == nil {
+			assert.NoError(t, test.Policy.Enforce(n), test.Q)
+		}
+		err = test.Policy.WithSchema(schema).Enforce(n)
+		if test.Err == "" {
+			assert.NoError(t, err, test.Q)
+		} else {
+			assert.EqualError(t, err, test.Err, test.Q)
+		}
+	}
+
+	var none *Policy
+	assert.Nil(t, none.WithSchema(schema))
+}
+
+func TestPolicyRedact(t *testing.T) {
+	p := &Policy{Deny: []string{"full_name", "Profile.secret", "Orders"}}
+	for _, test := range []struct {
+		Err *Error
+		E   []string
+	}{
+		{Err: NewError(ErrUnknownField).WithField("fullnam").WithSuggestions("fullnam", []string{"FullName", "UserName"})},
+		{Err: NewError(ErrUnknownField).WithField("Profile", "secre").WithSuggestions("secre", []string{"secret", "bio"})},
+		{Err: NewError(ErrUnknownField).WithField("Profile", "ag").WithSuggestions("ag", []string{"age"}), E: []string{"age"}},
+		{Err: NewError(ErrUnknownRelation).WithField("Order").WithSuggestions("Order", []string{"Orders"})},
+		{Err: NewError(ErrUnknownField).WithField("Address", "Cit").WithSuggestions("Address.Cit", []string{"Address.City"}), E: []string{"Address.City"}},
+	} {
+		err := p.Redact(test.Err)
+		assert.Equal(t, test.E, err.(*Error).Suggestions, test.Err.Field)
+	}
+
+	var none *Policy
+	err := NewError(ErrUnknownField).WithField("fullnam").WithSuggestions("fullnam", []string{"full_name"})
+	assert.Equal(t, []string{"full_name"}, none.Redact(err).(*Error).Suggestions)
+	assert.NoError(t, p.Redact(nil))
+}
@@ -0,0 +1,167 @@
+package miniquery
+
+import (
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// FieldResolver map public field path to the path known by backends,
+// applied to the AST before backend specific column resolution.
+type FieldResolver interface {
+	ResolveField(names []string) ([]string, error)
+}
+
+// FieldResolverFunc adapt function to FieldResolver
+type FieldResolverFunc func(names []string) ([]string, error)
+
+func (f FieldResolverFunc) ResolveField(names []string) ([]string, error) {
+	return f(names)
+}
+
+// FieldMapping alias based FieldResolver, the longest matched prefix is rewritten
+//
+//	Aliases: {"createdAt": "created_at", "owner": "owner_users", "owner.name": "owner_users.full_name"}
+type FieldMapping struct {
+	// Aliases public path to target path
+	Aliases map[string]string
+	// Deprecated old path to target path, reported by OnDeprecated
+	Deprecated map[string]string
+	// OnDeprecated called when deprecated path used, default log by slog
+	OnDeprecated func(old string, replacement string)
+	// Strict reject path not matched by Aliases or Deprecated
+	Strict bool
+}
+
+func (m *FieldMapping) ResolveField(names []string) ([]string, error) {
+	path := strings.Join(names, ".")
+	if out, ok := rewritePath(m.Aliases, names); ok {
+		return out, nil
+	}
+	if out, ok := rewritePath(m.Deprecated, names); ok {
+		replacement := strings.Join(out, ".")
+		if m.OnDeprecated != nil {
+			m.OnDeprecated(path, replacement)
+		} else {
+			slog.Warn("miniquery: deprecated field", "field", path, "replacement", replacement)
+		}
+		return out, nil
+	}
+	if m.Strict {
+		candidates := make([]string, 0, len(m.Aliases))
+		for k := range m.Aliases {
+			candidates = append(candidates, k)
+		}
+		sort.Strings(candidates)
+		return nil, NewError(ErrUnknownField).WithField(names...).WithSuggestions(path, candidates)
+	}
+	return names, nil
+}
+
+// rewritePath rewrite the longest matched prefix, exact match first then case-insensitive
+func rewritePath(aliases map[string]string, names []string) ([]string, bool) {
+	if len(aliases) == 0 {
+		return nil, false
+	}
+	for i := len(names); i > 0; i-- {
+		prefix := strings.Join(names[:i], ".")
+		target, ok := aliases[prefix]
+		if !ok {
+			folded := foldPath(names[:i])
+			for k, v := range aliases {
+				if foldPath(strings.Split(k, ".")) == folded {
+					target, ok = v, true
+					break
+				}
+			}
+		}
+		if ok {
+			return append(strings.Split(target, "."), names[i:]...), true
+		}
+	}
+	return nil, false
+}
+
+// Resolve rewrite identifiers and references by resolver in place
+func Resolve(node *Node, resolver FieldResolver) error {
+	if resolver == nil || node == nil {
+		return nil
+	}
+	return resolveNode(node, resolver, nil, nil)
+}
+
+// resolveNode resolve node under relation, public is the relation path in query, prefix is the resolved path
+func resolveNode(node *Node, resolver FieldResolver, public, prefix []string) error {
+	switch node.Type {
+	case ValueNodeType, OperationNodeType:
+		return nil
+	case IdentifierNodeType, ReferenceNodeType:
+		names := node.Names
+		if node.Type == IdentifierNodeType {
+			names = []string{node.Name}
+		}
+		out, err := resolvePath(resolver, public, prefix, names)
+		if err != nil {
+			return err
+		}
+		setPath(node, out)
+		return nil
+	case FunctionExpressionType:
+		params := node.Params
+		if RelationFunctions[node.Name] && len(params) > 0 && params[0].Type == IdentifierNodeType {
+			rel, err := resolvePath(resolver, public, prefix, []string{params[0].Name})
+			if err != nil {
+				return err
+			}
+			if len(rel) != 1 {
+				return NewError(ErrInvalidMapping).WithField(append(slices.Clone(public), params[0].Name)...).WithValue(strings.Join(rel, "."))
+			}
+			nextPublic := append(slices.Clone(public), params[0].Name)
+			next := append(slices.Clone(prefix), rel[0])
+			params[0].Name = rel[0]
+			for _, v := range params[1:] {
+				if err = resolveNode(v, resolver, nextPublic, next); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, v := range params {
+			if err := resolveNode(v, resolver, public, prefix); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, v := range append([]*Node{node.Left, node.Right, node.Expression}, node.Params...) {
+		if v == nil {
+			continue
+		}
+		if err := resolveNode(v, resolver, public, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolvePath resolve names under relation, the resolved path must stay under the resolved relation prefix
+func resolvePath(resolver FieldResolver, public, prefix, names []string) ([]string, error) {
+	full := append(slices.Clone(public), names...)
+	out, err := resolver.ResolveField(full)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) <= len(prefix) || !slices.Equal(out[:len(prefix)], prefix) {
+		return nil, NewError(ErrInvalidMapping).WithField(full...).WithValue(strings.Join(out, "."))
+	}
+	return out[len(prefix):], nil
+}
+
+func setPath(node *Node, names []string) {
+	if len(names) == 1 {
+		node.Type, node.Name, node.Names = IdentifierNodeType, names[0], nil
+	} else {
+		node.Type, node.Name, node.Names = ReferenceNodeType, "", names
+	}
+}
@@ -0,0 +1,60 @@
+package miniquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	var deprecated []string
+	m := &FieldMapping{
+		Aliases: map[string]string{
+			"createdAt":  "created_at",
+			"owner":      "owner_users",
+			"owner.name": "owner_users.full_name",
+			"status":     "state_code",
+			"age":        "Profile.age",
+		},
+		Deprecated: map[string]string{
+			"created": "created_at",
+		},
+		OnDeprecated: func(old string, replacement string) {
+			deprecated = append(deprecated, old+"->"+replacement)
+		},
+	}
+	for _, test := range []struct {
+		Q   string
+		E   string
+		Err bool
+	}{
+		{Q: `createdAt > 1 and CREATEDAT < 2`, E: `created_at > 1 && created_at < 2`},
+		{Q: `owner.name = 'a' and owner.id = 1`, E: `owner_users.full_name == "a" && owner_users.id == 1`},
+		{Q: `status in ['a'] and other = 1`, E: `state_code in ["a"] && other == 1`},
+		{Q: `age > 18`, E: `Profile.age > 18`},
+		{Q: `date(createdAt) = '2021-01-01'`, E: `date(created_at) == "2021-01-01"`},
+		{Q: `has_edge(owner, name = 'a')`, E: `has_edge(owner_users,full_name == "a")`},
+		{Q: `created is null`, E: `created_at is null`},
+	} {
+		n, err := Parse(test.Q)
+		if !assert.NoError(t, err, test.Q) {
+			continue
+		}
+		err = Resolve(n, m)
+		if test.Err {
+			assert.Error(t, err, test.Q)
+			continue
+		}
+		if assert.NoError(t, err, test.Q) {
+			assert.Equal(t, test.E, Build(n))
+		}
+	}
+	assert.Equal(t, []string{"created->created_at"}, deprecated)
+
+	m.Strict = true
+	n, _ := Parse(`other = 1`)
+	assert.EqualError(t, Resolve(n, m), `field not found: "other"`)
+	m.Aliases["owner.escape"] = "escape"
+	n, _ = Parse(`has_edge(owner, escape = 1)`)
+	assert.ErrorIs(t, Resolve(n, m), ErrInvalidMapping)
+}
@@ -0,0 +1,195 @@
+package miniquery
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+type FieldType string
+
+const (
+	StringFieldType FieldType = "string"
+	IntFieldType    FieldType = "int"
+	FloatFieldType  FieldType = "float"
+	BoolFieldType   FieldType = "bool"
+	TimeFieldType   FieldType = "time"
+	UUIDFieldType   FieldType = "uuid"
+	JSONFieldType   FieldType = "json"
+)
+
+// Schema describe filterable fields and relations of a model
+type Schema struct {
+	Name      string             `json:"name,omitempty"`
+	Fields    []*SchemaField     `json:"fields"`
+	Relations map[string]*Schema `json:"relations,omitempty"`
+	// ToMany relations reference many rows, can be filtered by has_edge but not sorted
+	ToMany []string `json:"toMany,omitempty"`
+}
+
+// SchemaField describe a filterable field
+type SchemaField struct {
+	Name      string    `json:"name"`
+	Column    string    `json:"column,omitempty"` // optional column name, also accepted as field name
+	Type      FieldType `json:"type"`
+	Nullable  bool      `json:"nullable,omitempty"`
+	Unique    bool      `json:"unique,omitempty"`    // primary key or unique column, cursor order requires one
+	Operators []OpType  `json:"operators,omitempty"` // allowed operators, default by DefaultOperators of the type
+	Enum      []string  `json:"enum,omitempty"`      // known values
+}
+
+// DefaultOperators allowed operators of field types
+var DefaultOperators = map[FieldType][]OpType{
+	StringFieldType: {OpEQ, OpNEQ, OpGT, OpGTE, OpLT, OpLTE, OpLike, OpNotLike, OpIn, OpNotIn, OpBetween, OpNotBetween},
+	IntFieldType:    {OpEQ, OpNEQ, OpGT, OpGTE, OpLT, OpLTE, OpIn, OpNotIn, OpBetween, OpNotBetween},
+	FloatFieldType:  {OpEQ, OpNEQ, OpGT, OpGTE, OpLT, OpLTE, OpIn, OpNotIn, OpBetween, OpNotBetween},
+	TimeFieldType:   {OpEQ, OpNEQ, OpGT, OpGTE, OpLT, OpLTE, OpBetween, OpNotBetween},
+	BoolFieldType:   {OpEQ, OpNEQ, OpIsTrue, OpIsNotTrue, OpIsFalse, OpIsNotFalse},
+	UUIDFieldType:   {OpEQ, OpNEQ, OpIn, OpNotIn},
+	JSONFieldType:   {},
+}
+
+// JSONPathOperators allowed operators of values extracted by json path like Settings.theme, values are not coerced
+var JSONPathOperators = []OpType{OpEQ, OpNEQ, OpGT, OpGTE, OpLT, OpLTE, OpLike, OpNotLike, OpIn, OpNotIn, OpBetween, OpNotBetween}
+
+// AllowedOperators return allowed operators of the field, null checks are always allowed
+func (f *SchemaField) AllowedOperators() []OpType {
+	ops := f.Operators
+	if ops == nil {
+		ops = DefaultOperators[f.Type]
+	}
+	return append(slices.Clone(ops), OpIsNull, OpIsNotNull)
+}
+
+// AllowOperator check whether operator is allowed for the field
+func (f *SchemaField) AllowOperator(op OpType) bool {
+	return slices.Contains(f.AllowedOperators(), op)
+}
+
+// Field find field by name, match name or column case-insensitively, underscores are ignored so createdAt matches created_at
+func (s *Schema) Field(name string) *SchemaField {
+	for _, f := range s.Fields {
+		if f.Name == name || f.Column == name {
+			return f
+		}
+	}
+	for _, f := range s.Fields {
+		if strings.EqualFold(f.Name, name) || strings.EqualFold(f.Column, name) {
+			return f
+		}
+	}
+	folded := foldName(name)
+	for _, f := range s.Fields {
+		if foldName(f.Name) == folded || (f.Column != "" && foldName(f.Column) == folded) {
+			return f
+		}
+	}
+	return nil
+}
+
+// Relation find relation by name case-insensitively
+func (s *Schema) Relation(name string) *Schema {
+	if r, ok := s.Relations[name]; ok {
+		return r
+	}
+	for k, r := range s.Relations {
+		if strings.EqualFold(k, name) || foldName(k) == foldName(name) {
+			return r
+		}
+	}
+	return nil
+}
+
+// IsToMany check whether relation reference many rows
+func (s *Schema) IsToMany(name string) bool {
+	for _, v := range s.ToMany {
+		if foldName(v) == foldName(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup find field by path, leading names are relations
+func (s *Schema) Lookup(names []string) (*SchemaField, error) {
+	cur := s
+	for i, name := range names[:len(names)-1] {
+		next := cur.Relation(name)
+		if next == nil {
+			// fields of embedded struct are named by path, names after json field are json path
+			if f := cur.Field(strings.Join(names[i:], ".")); f != nil {
+				return f, nil
+			}
+			if f := cur.Field(name); f != nil && f.Type == JSONFieldType {
+				return &SchemaField{Name: strings.Join(names[i:], "."), Type: JSONFieldType, Nullable: true, Operators: JSONPathOperators}, nil
+			}
+			if embedded := cur.embeddedFieldNames(names[:i+1]); len(embedded) != 0 {
+				return nil, NewError(ErrUnknownField).WithField(names...).WithSuggestions(strings.Join(names[i:], "."), embedded)
+			}
+			return nil, NewError(ErrUnknownRelation).WithField(names[:i+1]...).WithSuggestions(name, cur.RelationNames())
+		}
+		cur = next
+	}
+	name := names[len(names)-1]
+	f := cur.Field(name)
+	if f == nil {
+		return nil, NewError(ErrUnknownField).WithField(names...).WithSuggestions(name, cur.FieldNames())
+	}
+	return f, nil
+}
+
+// embeddedFieldNames names of fields of the embedded struct path
+func (s *Schema) embeddedFieldNames(names []string) []string {
+	prefix := strings.Join(names, ".") + "."
+	var out []string
+	for _, f := range s.Fields {
+		if len(f.Name) > len(prefix) && strings.EqualFold(f.Name[:len(prefix)], prefix) {
+			out = append(out, f.Name)
+		}
+	}
+	return out
+}
+
+// FieldNames names of fields
+func (s *Schema) FieldNames() []string {
+	out := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		out[i] = f.Name
+	}
+	return out
+}
+
+// RelationNames sorted names of relations
+func (s *Schema) RelationNames() []string {
+	out := make([]string, 0, len(s.Relations))
+	for k := range s.Relations {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// maxWalkDepth relation depth walked by walkFields, relations may reference back
+const maxWalkDepth = 2
+
+// walkFields call fn with path of fields and fields of relations, toMany if any relation of the path is to-many,
+// relations are skipped when fn return false for the relation path with nil field
+func walkFields(s *Schema, prefix []string, toMany bool, fn func(names []string, f *SchemaField, toMany bool) bool) {
+	for _, f := range s.Fields {
+		fn(append(slices.Clone(prefix), f.Name), f, toMany)
+	}
+	if len(prefix) >= maxWalkDepth {
+		return
+	}
+	for _, name := range s.RelationNames() {
+		names := append(slices.Clone(prefix), name)
+		many := toMany || s.IsToMany(name)
+		if fn(names, nil, many) {
+			walkFields(s.Relations[name], names, many, fn)
+		}
+	}
+}
+
+func foldName(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+}
@@ -0,0 +1,64 @@
+package miniquery
+
+import (
+	"sort"
+)
+
+// Suggest return candidates similar to name by case-folded edit distance, closest first, at most 3
+func Suggest(name string, candidates []string) []string {
+	folded := foldName(name)
+	limit := max(1, len([]rune(folded))/3)
+	type scored struct {
+		name     string
+		distance int
+	}
+	var matched []scored
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		fc := foldName(c)
+		if seen[fc] || c == name {
+			continue
+		}
+		seen[fc] = true
+		if d := editDistance(folded, fc); d <= limit {
+			matched = append(matched, scored{c, d})
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].distance < matched[j].distance
+	})
+	var out []string
+	for i, v := range matched {
+		if i == 3 {
+			break
+		}
+		out = append(out, v.name)
+	}
+	return out
+}
+
+// editDistance optimal string alignment distance, adjacent transposition count as one edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,37 @@
+package miniquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"username", "full_name", "created_at", "CreatedAt", "id", "Profile"}
+	assert.Equal(t, []string{"username"}, Suggest("usrname", candidates))
+	assert.Equal(t, []string{"created_at"}, Suggest("createdAt", candidates))
+	assert.Equal(t, []string{"created_at"}, Suggest("creatdat", candidates))
+	assert.Equal(t, []string{"Profile"}, Suggest("profle", candidates))
+	assert.Equal(t, []string{"id"}, Suggest("ic", candidates))
+	assert.Empty(t, Suggest("xyz", candidates))
+	assert.Empty(t, Suggest("username", candidates))
+
+	s := &Schema{
+		Fields:    []*SchemaField{{Name: "username"}, {Name: "age"}},
+		Relations: map[string]*Schema{"Profile": {Fields: []*SchemaField{{Name: "bio"}}}},
+	}
+	_, err := s.Lookup([]string{"usrname"})
+	assert.EqualError(t, err, `field not found: "usrname", did you mean "username"?`)
+	assert.Equal(t, `字段不存在: "usrname"，是否要使用 "username"？`, Localize(err, "zh"))
+	_, err = s.Lookup([]string{"Profle", "bio"})
+	assert.EqualError(t, err, `relation not found: "Profle", did you mean "Profile"?`)
+	_, err = s.Lookup([]string{"Profile", "boi"})
+	var e *Error
+	assert.ErrorAs(t, err, &e)
+	assert.Equal(t, []string{"bio"}, e.Suggestions)
+
+	_, err = Compile[struct{ Username string }](mustParse(t, `usrname = 'a'`))
+	assert.EqualError(t, err, `field not found: "usrname", did you mean "Username"?`)
+	_, err = Compile[struct{ Username string }](mustParse(t, `dat(Username) = 1`))
+	assert.EqualError(t, err, `unsupported function: "dat", did you mean "date"?`)
+}
@@ -0,0 +1,150 @@
+package miniquery
+
+import (
+	"errors"
+	"strings"
+)
+
+// TokenKind kind of token
+type TokenKind string
+
+const (
+	KeywordToken    TokenKind = "keyword"
+	OperatorToken   TokenKind = "operator"
+	IdentifierToken TokenKind = "identifier"
+	ReferenceToken  TokenKind = "reference"
+	StringToken     TokenKind = "string"
+	NumberToken     TokenKind = "number"
+	ParameterToken  TokenKind = "parameter"
+	FunctionToken   TokenKind = "function"
+	CommentToken    TokenKind = "comment"
+	ErrorToken      TokenKind = "error"
+)
+
+// Token highlight token of input[Start:End]
+type Token struct {
+	Kind  TokenKind `json:"kind"`
+	Text  string    `json:"text"`
+	Start int       `json:"start"` // byte offset
+	End   int       `json:"end"`   // byte offset
+}
+
+// tokenRank higher rank override lower when rule ranges overlap
+var tokenRank = map[TokenKind]int{
+	IdentifierToken: 1,
+	ReferenceToken:  2,
+	FunctionToken:   2,
+	CommentToken:    3,
+}
+
+// operatorWords captured words of in and between rules, other operators have their own rules
+var operatorWords = map[string]bool{"in": true, "not in": true, "between": true, "not between": true}
+
+// Tokenize split input to highlight tokens by the syntax tree of the parser,
+// whitespace and punctuations are not tokens, the input after the error position is one error token.
+func Tokenize(input string) []Token {
+	p := &MiniQueryPeg{Tree: &Tree{}, Buffer: input}
+	if err := p.Init(); err != nil {
+		return nil
+	}
+	runes := []rune(input)
+	n := len(runes)
+	valid := n
+	if err := p.Parse(); err != nil {
+		var pe *parseError
+		if !errors.As(err, &pe) {
+			return nil
+		}
+		// the tree keeps tokens of the parsed prefix
+		valid = min(int(pe.max.end), n)
+	}
+
+	kinds := make([]TokenKind, n)
+	mark := func(kind TokenKind, begin, end uint32) {
+		for i := int(begin); i < int(end) && i < valid; i++ {
+			if kinds[i] == "" || tokenRank[kinds[i]] < tokenRank[kind] {
+				kinds[i] = kind
+			}
+		}
+	}
+	// words and symbols of operator rules like `>=`, `not like`, `is not null`, spaces and comments are skipped
+	markOperator := func(begin, end uint32) {
+		for i := int(begin); i < int(end) && i < valid; i++ {
+			if c := runes[i]; c < 128 && (isNameByte(byte(c)) || isOperatorByte(byte(c))) {
+				mark(OperatorToken, uint32(i), uint32(i+1))
+			}
+		}
+	}
+	tokens := p.Tokens()
+	calls := map[uint32]bool{}
+	for _, t := range tokens {
+		if t.pegRule == ruleArgumentList {
+			calls[t.begin] = true
+		}
+	}
+	for _, t := range tokens {
+		switch t.pegRule {
+		case ruleIdentifier:
+			if calls[t.end] {
+				mark(FunctionToken, t.begin, t.end)
+			} else {
+				mark(IdentifierToken, t.begin, t.end)
+			}
+		case ruleIdentifierReference, ruleJsonReference:
+			mark(ReferenceToken, t.begin, t.end)
+		case ruleString:
+			mark(StringToken, t.begin, t.end)
+		case ruleInteger, ruleFloat:
+			mark(NumberToken, t.begin, t.end)
+		case ruleBoolean, ruleNull:
+			mark(KeywordToken, t.begin, t.end)
+		case ruleParameter:
+			mark(ParameterToken, t.begin, t.end)
+		case ruleCompare, ruleMatch:
+			markOperator(t.begin, t.end)
+		case rulePegText:
+			if operatorWords[strings.Join(strings.Fields(strings.ToLower(string(runes[t.begin:t.end]))), " ")] {
+				markOperator(t.begin, t.end)
+			}
+		case ruleComment:
+			end := t.end
+			for end > t.begin && (runes[end-1] == '\n' || runes[end-1] == '\r') {
+				end--
+			}
+			mark(CommentToken, t.begin, end)
+		}
+	}
+	// literal words and symbols of rules like `and`, `not`, `&&`
+	for i := 0; i < valid; i++ {
+		switch c := runes[i]; {
+		case kinds[i] != "":
+		case c < 128 && isNameByte(byte(c)) && c != '.':
+			kinds[i] = KeywordToken
+		case c < 128 && isOperatorByte(byte(c)):
+			kinds[i] = OperatorToken
+		}
+	}
+	for i := valid; i < n; i++ {
+		kinds[i] = ErrorToken
+	}
+	for i := valid; i < n && strings.ContainsRune(" \t\r\n", runes[i]); i++ {
+		kinds[i] = ""
+	}
+
+	var out []Token
+	offset := 0
+	for i := 0; i < n; {
+		j := i + 1
+		size := len(string(runes[i]))
+		for j < n && kinds[j] == kinds[i] {
+			size += len(string(runes[j]))
+			j++
+		}
+		if kinds[i] != "" {
+			out = append(out, Token{Kind: kinds[i], Text: input[offset : offset+size], Start: offset, End: offset + size})
+		}
+		offset += size
+		i = j
+	}
+	return out
+}
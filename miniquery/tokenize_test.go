@@ -0,0 +1,56 @@
+package miniquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	type tk struct {
+		Kind TokenKind
+		Text string
+	}
+	for _, test := range []struct {
+		Q string
+		E []tk
+	}{
+		{Q: `age >= 18`, E: []tk{{IdentifierToken, "age"}, {OperatorToken, ">="}, {NumberToken, "18"}}},
+		{Q: `a = 'x y' && Profile.age != :age`, E: []tk{
+			{IdentifierToken, "a"}, {OperatorToken, "="}, {StringToken, "'x y'"}, {OperatorToken, "&&"},
+			{ReferenceToken, "Profile.age"}, {OperatorToken, "!="}, {ParameterToken, ":age"},
+		}},
+		{Q: `not b not in [1, 2] or c is not null`, E: []tk{
+			{KeywordToken, "not"}, {IdentifierToken, "b"}, {OperatorToken, "not"}, {OperatorToken, "in"}, {NumberToken, "1"}, {NumberToken, "2"},
+			{KeywordToken, "or"}, {IdentifierToken, "c"}, {OperatorToken, "is"}, {OperatorToken, "not"}, {OperatorToken, "null"},
+		}},
+		{Q: "date(createdAt) between \"2024-01-01\" and \"2024-02-01\" or updatedAt < now() -- recent\nor x like 'a%'", E: []tk{
+			{FunctionToken, "date"}, {IdentifierToken, "createdAt"}, {OperatorToken, "between"}, {StringToken, `"2024-01-01"`}, {KeywordToken, "and"}, {StringToken, `"2024-02-01"`},
+			{KeywordToken, "or"}, {IdentifierToken, "updatedAt"}, {OperatorToken, "<"}, {FunctionToken, "now"}, {CommentToken, "-- recent"}, {KeywordToken, "or"}, {IdentifierToken, "x"}, {OperatorToken, "like"}, {StringToken, "'a%'"},
+		}},
+		{Q: `a gt 1 or b eq 2 or c not like 'x' or d not between 1 and 2 or e is true or f in [1]`, E: []tk{
+			{IdentifierToken, "a"}, {OperatorToken, "gt"}, {NumberToken, "1"}, {KeywordToken, "or"}, {IdentifierToken, "b"}, {OperatorToken, "eq"}, {NumberToken, "2"},
+			{KeywordToken, "or"}, {IdentifierToken, "c"}, {OperatorToken, "not"}, {OperatorToken, "like"}, {StringToken, "'x'"},
+			{KeywordToken, "or"}, {IdentifierToken, "d"}, {OperatorToken, "not"}, {OperatorToken, "between"}, {NumberToken, "1"}, {KeywordToken, "and"}, {NumberToken, "2"},
+			{KeywordToken, "or"}, {IdentifierToken, "e"}, {OperatorToken, "is"}, {OperatorToken, "true"}, {KeywordToken, "or"}, {IdentifierToken, "f"}, {OperatorToken, "in"}, {NumberToken, "1"},
+		}},
+		{Q: `in in [1]`, E: []tk{{IdentifierToken, "in"}, {OperatorToken, "in"}, {NumberToken, "1"}}},
+		{Q: `a > -- x
+1`, E: []tk{{IdentifierToken, "a"}, {OperatorToken, ">"}, {CommentToken, "-- x"}, {NumberToken, "1"}}},
+		{Q: `active = true`, E: []tk{{IdentifierToken, "active"}, {OperatorToken, "="}, {KeywordToken, "true"}}},
+		{Q: `名 = '值' and a = 1`, E: []tk{{ErrorToken, `名 = '值' and a = 1`}}},
+		{Q: `a = '值' and b = 1`, E: []tk{{IdentifierToken, "a"}, {OperatorToken, "="}, {StringToken, "'值'"}, {KeywordToken, "and"}, {IdentifierToken, "b"}, {OperatorToken, "="}, {NumberToken, "1"}}},
+		{Q: `age > 1 a = 2`, E: []tk{{IdentifierToken, "age"}, {OperatorToken, ">"}, {NumberToken, "1"}, {ErrorToken, "a = 2"}}},
+		{Q: `age > 1 and`, E: []tk{{IdentifierToken, "age"}, {OperatorToken, ">"}, {NumberToken, "1"}, {KeywordToken, "and"}}},
+		{Q: ``},
+	} {
+		t.Run(test.Q, func(t *testing.T) {
+			var out []tk
+			for _, v := range Tokenize(test.Q) {
+				assert.Equal(t, v.Text, test.Q[v.Start:v.End])
+				out = append(out, tk{v.Kind, v.Text})
+			}
+			assert.Equal(t, test.E, out)
+		})
+	}
+}
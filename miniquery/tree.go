@@ -81,7 +81,7 @@ func (t *Tree) PopFunction() {
 	a := t.Pop()
 	n := t.Pop()
 	if a == nil || n == nil {
-		t.AddError(fmt.Errorf("invalid function exp %q %q", n, a))
+		t.AddError(NewError(ErrSyntax).WithValue(fmt.Sprintf("%v(%v)", n, a)))
 		return
 	}
 	if n.Type == IdentifierNodeType && a.Type == ValueNodeType && a.ValueType == ArrayValueType {
@@ -91,7 +91,7 @@ func (t *Tree) PopFunction() {
 			Params: a.Array,
 		})
 	} else {
-		t.AddError(fmt.Errorf("invalid function exp %q %q", n.Type, a.Type))
+		t.AddError(NewError(ErrSyntax).WithValue(fmt.Sprintf("%v(%v)", n.Type, a.Type)))
 	}
 }
 
@@ -236,6 +236,12 @@ func (t *Tree) AddString(s string) {
 	})
 }
 
+// unquote unescape doubled quote of string literal
+func unquote(s string, quote byte) string {
+	q := string(quote)
+	return strings.ReplaceAll(s, q+q, q)
+}
+
 func (t *Tree) AddFloat(s string) {
 	v, err := strconv.ParseFloat(s, 64)
 	t.AddError(err)
@@ -272,3 +278,10 @@ func (t *Tree) AddInteger(s string) {
 		Int:       i,
 	})
 }
+
+func (t *Tree) AddParameter(s string) {
+	t.Push(&Node{
+		Type: ParameterNodeType,
+		Name: s,
+	})
+}
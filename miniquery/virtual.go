@@ -0,0 +1,245 @@
+package miniquery
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Virtuals registry of virtual fields, expanded to their definitions on the AST before backends build
+//
+//	v.Define("overdue", "due_at < now() and status != 'done'")
+//	v.Define("mine", "owner_id = :currentUser") // bound by Expand params
+//	v.Define("older_than", "age > :age", "age") // older_than(18)
+type Virtuals struct {
+	fields map[string]*VirtualField
+}
+
+// VirtualField condition defined by miniquery expression
+type VirtualField struct {
+	Name       string
+	Params     []string // parameters bound by call arguments
+	Expression *Node
+}
+
+// Define register virtual field, params are bound positionally when used as function
+func (v *Virtuals) Define(name string, expr string, params ...string) error {
+	node, err := Parse(expr)
+	if err != nil {
+		return NewError(ErrInvalidVirtual).WithField(name).Wrap(err)
+	}
+	if !node.IsExpression() {
+		return NewError(ErrVirtualNotCondition).WithField(name)
+	}
+	if v.fields == nil {
+		v.fields = map[string]*VirtualField{}
+	}
+	v.fields[strings.ToLower(name)] = &VirtualField{Name: name, Params: params, Expression: node}
+	return nil
+}
+
+// MustDefine like Define but panic on error
+func (v *Virtuals) MustDefine(name string, expr string, params ...string) *Virtuals {
+	if err := v.Define(name, expr, params...); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Lookup find virtual field by name case-insensitively
+func (v *Virtuals) Lookup(name string) *VirtualField {
+	if v == nil {
+		return nil
+	}
+	return v.fields[strings.ToLower(name)]
+}
+
+// Names return names of all virtual fields
+func (v *Virtuals) Names() []string {
+	if v == nil {
+		return nil
+	}
+	out := make([]string, 0, len(v.fields))
+	for _, f := range v.fields {
+		out = append(out, f.Name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Resolver keep names of virtual fields and resolve others by r, used to Resolve the query before Expand,
+// definitions of virtual fields are written with backend names and must not be resolved.
+func (v *Virtuals) Resolver(r FieldResolver) FieldResolver {
+	if r == nil {
+		return nil
+	}
+	return FieldResolverFunc(func(names []string) ([]string, error) {
+		if len(names) == 1 && v.Lookup(names[0]) != nil {
+			return names, nil
+		}
+		return r.ResolveField(names)
+	})
+}
+
+// maxExpandDepth limit nested virtual fields
+const maxExpandDepth = 16
+
+// Expand replace virtual fields by their definitions and bind parameters, return the expanded root.
+// Virtual fields can be used as condition `overdue`, `overdue = false`, `overdue is true` or function `older_than(18)`.
+func Expand(node *Node, virtuals *Virtuals, params map[string]interface{}) (*Node, error) {
+	if node == nil {
+		return nil, nil
+	}
+	e := &expander{virtuals: virtuals, params: params}
+	return e.expand(node, nil, false)
+}
+
+type expander struct {
+	virtuals *Virtuals
+	params   map[string]interface{}
+	stack    []string
+}
+
+// expand return the replacement of node, scope is the bound arguments of the virtual being expanded
+func (e *expander) expand(node *Node, scope map[string]*Node, relation bool) (out *Node, err error) {
+	switch node.Type {
+	case ParameterNodeType:
+		return e.bind(node, scope)
+	case IdentifierNodeType:
+		if f := e.lookup(node.Name, relation); f != nil {
+			return e.expandVirtual(f, nil, scope)
+		}
+		return node, nil
+	case FunctionExpressionType:
+		if f := e.lookup(node.Name, relation); f != nil {
+			return e.expandVirtual(f, node.Params, scope)
+		}
+		params := node.Params
+		if RelationFunctions[node.Name] && len(params) > 0 {
+			for i, v := range params[1:] {
+				if params[i+1], err = e.expand(v, scope, true); err != nil {
+					return
+				}
+			}
+			return node, nil
+		}
+	case CompareExpressionType, PredicatesExpressionType:
+		if out, ok, err := e.expandCondition(node, scope, relation); ok || err != nil {
+			return out, err
+		}
+		fallthrough
+	case BetweenExpressionType:
+		// virtual field can only be used as condition
+		for _, v := range []*Node{node.Left, node.Right} {
+			if v != nil && v.Type == IdentifierNodeType && e.lookup(v.Name, relation) != nil {
+				return nil, NewError(ErrVirtualNotCondition).WithField(v.Name).WithValue(Build(node))
+			}
+		}
+	}
+	if err = e.expandChildren(node, scope, relation); err != nil {
+		return
+	}
+	return node, nil
+}
+
+func (e *expander) expandChildren(node *Node, scope map[string]*Node, relation bool) (err error) {
+	expand := func(v **Node) {
+		if err == nil && *v != nil {
+			*v, err = e.expand(*v, scope, relation)
+		}
+	}
+	expand(&node.Expression)
+	expand(&node.Left)
+	expand(&node.Right)
+	for i := range node.Params {
+		expand(&node.Params[i])
+	}
+	for i := range node.Array {
+		expand(&node.Array[i])
+	}
+	if err == nil && node.ValueType == ArrayValueType {
+		// in (:ids) with bound array
+		var flat []*Node
+		for _, v := range node.Array {
+			if v.ValueType == ArrayValueType {
+				flat = append(flat, v.Array...)
+			} else {
+				flat = append(flat, v)
+			}
+		}
+		node.Array = flat
+	}
+	return
+}
+
+// expandCondition handle virtual = true, virtual != false, virtual is true ...
+func (e *expander) expandCondition(node *Node, scope map[string]*Node, relation bool) (*Node, bool, error) {
+	field, value := node.Left, node.Right
+	if node.Type == CompareExpressionType && field.Type != IdentifierNodeType {
+		field, value = value, field
+	}
+	if field.Type != IdentifierNodeType || e.lookup(field.Name, relation) == nil {
+		return nil, false, nil
+	}
+	var positive bool
+	op := node.Op.Operation
+	switch {
+	case node.Type == PredicatesExpressionType && (op == OpIsTrue || op == OpIsNotFalse):
+		positive = true
+	case node.Type == PredicatesExpressionType && (op == OpIsFalse || op == OpIsNotTrue):
+	case value.Type == ValueNodeType && value.ValueType == BooleanValueType && (op == OpEQ || op == OpNEQ):
+		positive = value.Bool == (op == OpEQ)
+	default:
+		return nil, true, NewError(ErrVirtualNotCondition).WithField(field.Name).WithValue(Build(node))
+	}
+	out, err := e.expand(field, scope, relation)
+	if err != nil || positive {
+		return out, true, err
+	}
+	return Not(out), true, nil
+}
+
+func (e *expander) expandVirtual(f *VirtualField, args []*Node, scope map[string]*Node) (*Node, error) {
+	if len(args) != len(f.Params) {
+		return nil, NewError(ErrInvalidArguments).WithFunction(f.Name).WithExpected(fmt.Sprintf("%d arguments, got %d", len(f.Params), len(args)))
+	}
+	if slices.Contains(e.stack, f.Name) || len(e.stack) >= maxExpandDepth {
+		return nil, NewError(ErrRecursiveVirtual).WithField(f.Name).WithValue(strings.Join(append(e.stack, f.Name), " -> "))
+	}
+	bound := map[string]*Node{}
+	for i, name := range f.Params {
+		arg, err := e.expand(args[i], scope, false)
+		if err != nil {
+			return nil, err
+		}
+		bound[name] = arg
+	}
+	e.stack = append(e.stack, f.Name)
+	defer func() {
+		e.stack = e.stack[:len(e.stack)-1]
+	}()
+	out, err := e.expand(f.Expression.Clone(), bound, false)
+	if err != nil {
+		return nil, err
+	}
+	return parentheses(out), nil
+}
+
+func (e *expander) bind(node *Node, scope map[string]*Node) (*Node, error) {
+	if v, ok := scope[node.Name]; ok {
+		return v.Clone(), nil
+	}
+	v, ok := e.params[node.Name]
+	if !ok {
+		return nil, NewError(ErrUnboundParameter).WithValue(node.Name)
+	}
+	return NewValue(v)
+}
+
+func (e *expander) lookup(name string, relation bool) *VirtualField {
+	if relation {
+		return nil
+	}
+	return e.virtuals.Lookup(name)
+}
@@ -0,0 +1,72 @@
+package miniquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpand(t *testing.T) {
+	v := &Virtuals{}
+	v.MustDefine("overdue", `due_at < now() and status != 'done'`).
+		MustDefine("mine", `owner_id = :currentUser`).
+		MustDefine("owned", `owner_id is not null`).
+		MustDefine("older_than", `age > :age`, "age").
+		MustDefine("my_overdue", `mine and overdue`).
+		MustDefine("loop", `a = 1 or loop`)
+	assert.Equal(t, []string{"loop", "mine", "my_overdue", "older_than", "overdue", "owned"}, v.Names())
+	assert.Error(t, v.Define("bad", `a =`))
+	assert.Error(t, v.Define("bad", `a`))
+
+	params := map[string]interface{}{"currentUser": 10, "ids": []int{1, 2}}
+	for _, test := range []struct {
+		Q   string
+		E   string
+		Err bool
+	}{
+		{Q: `overdue`, E: `(due_at < now() && status != "done")`},
+		{Q: `mine and name = 'a'`, E: `(owner_id == 10) && name == "a"`},
+		{Q: `Owned = true or overdue = false`, E: `(owner_id is not null) || not (due_at < now() && status != "done")`},
+		{Q: `owned is not true`, E: `not (owner_id is not null)`},
+		{Q: `not older_than(18)`, E: `not (age > 18)`},
+		{Q: `my_overdue`, E: `((owner_id == 10) && (due_at < now() && status != "done"))`},
+		{Q: `id in (:ids) or id = :currentUser`, E: `id in [1,2] || id == 10`},
+		{Q: `has_edge(Orders, owned)`, E: `has_edge(Orders,owned)`},
+		{Q: `a = :missing`, Err: true},
+		{Q: `overdue > 1`, Err: true},
+		{Q: `overdue between 1 and 2`, Err: true},
+		{Q: `older_than()`, Err: true},
+		{Q: `loop`, Err: true},
+	} {
+		n, err := Parse(test.Q)
+		if !assert.NoError(t, err, test.Q) {
+			continue
+		}
+		n, err = Expand(n, v, params)
+		if test.Err {
+			assert.Error(t, err, test.Q)
+			continue
+		}
+		if assert.NoError(t, err, test.Q) {
+			assert.Equal(t, test.E, Build(n), test.Q)
+			_, err = Parse(Build(n))
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestVirtualsResolver(t *testing.T) {
+	v := (&Virtuals{}).MustDefine("mine", `owner_id = :me`)
+	assert.Nil(t, v.Resolver(nil))
+
+	resolver := v.Resolver(&FieldMapping{Aliases: map[string]string{"name": "full_name"}, Strict: true})
+	n, err := Parse(`Mine and name = 'a'`)
+	assert.NoError(t, err)
+	assert.NoError(t, Resolve(n, resolver))
+	n, err = Expand(n, v, map[string]interface{}{"me": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `(owner_id == 1) && full_name == "a"`, Build(n))
+
+	n, _ = Parse(`owner_id = 1`)
+	assert.ErrorIs(t, Resolve(n, resolver), ErrUnknownField)
+}
@@ -2,8 +2,6 @@ package miniquery
 
 import (
 	"context"
-
-	"github.com/pkg/errors"
 )
 
 type (
@@ -131,5 +129,5 @@ func (v Visitor) Visit(ctx context.Context, n *Node) error {
 	case FunctionExpressionType:
 		return v.BetweenExpression(ctx, n)
 	}
-	return errors.Errorf("invalid type %q", n.Type)
+	return NewError(ErrInvalidNode).WithValue(string(n.Type))
 }